@@ -0,0 +1,85 @@
+package zerologctx
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Interface-typed variables and fields (any, or a project's own interface)
+// may hold a logger, Event or builder. Their facts record the kind of the
+// tracked value assigned to them, so that asserting it back —
+// v.(zerolog.Logger), a comma-ok assertion or a type switch case — yields a
+// value with context when the tracked value had one. Only the kind is
+// recorded: asserting *zerolog.Logger from a variable holding a
+// zerolog.Logger with context, which fails at run time, counts too.
+
+// isIfaceType reports whether t is an interface type. Type parameters, whose
+// underlying type is their constraint, are not.
+func isIfaceType(t types.Type) bool {
+	if _, ok := t.(*types.TypeParam); ok {
+		return false
+	}
+	return types.IsInterface(t)
+}
+
+// ifaceFact returns the fact of an interface-typed variable assigned rhs:
+// positive for the kind of a tracked value with context, or that of the
+// interface variable it is copied from (possibly through a conversion such
+// as any(l)).
+func (s *state) ifaceFact(rhs ast.Expr, at token.Pos) factKind {
+	rhs = ast.Unparen(rhs)
+	if call, ok := rhs.(*ast.CallExpr); ok && len(call.Args) == 1 && s.pass.TypesInfo.Types[call.Fun].IsType() {
+		return s.ifaceFact(call.Args[0], at)
+	}
+	t := s.pass.TypesInfo.TypeOf(rhs)
+	if tk := s.prof.kindOf(t); tk != trackNone {
+		if s.exprHasCtx(tk, rhs, at) {
+			return positiveFactFor(tk)
+		}
+		return factNone
+	}
+	if t == nil || !isIfaceType(t) {
+		return factNone
+	}
+	if obj := s.objectFromExpr(rhs); obj != nil && s.globalLogger(obj) == "" {
+		return s.facts.at(obj, at)
+	}
+	return factNone
+}
+
+// handleTypeSwitch records the facts of the variables a type switch binds
+// (switch l := v.(type)), one per case clause: a tracked case type gets a
+// positive fact when v holds a value of that kind with context, and an
+// interface-typed one (several types in the case, or default) copies v's.
+func (s *state) handleTypeSwitch(node *ast.TypeSwitchStmt) {
+	assign, ok := node.Assign.(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 {
+		return
+	}
+	ta, ok := ast.Unparen(assign.Rhs[0]).(*ast.TypeAssertExpr)
+	if !ok {
+		return
+	}
+	for _, stmt := range node.Body.List {
+		clause, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		obj := s.pass.TypesInfo.Implicits[clause]
+		if obj == nil {
+			continue
+		}
+		tk := s.prof.kindOf(obj.Type())
+		switch {
+		case tk != trackNone:
+			kind := factNone
+			if s.factIs(ta.X, clause.Pos(), positiveFactFor(tk)) {
+				kind = positiveFactFor(tk)
+			}
+			s.facts.set(obj, clause.Pos(), kind)
+		case isIfaceType(obj.Type()):
+			s.facts.set(obj, clause.Pos(), s.ifaceFact(ta.X, clause.Pos()))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Command logctx runs every analyzer of the zerologctx family — zerologctx,
+// slogctx, logrctx, zapctx, undecoratedctx and globalctxlogger — in one pass.
+// Each is enabled or disabled by its own flag (-slogctx=false skips log/slog;
+// -zerologctx alone runs only zerologctx) and its findings are silenced with
+// //nolint:NAME. Flags are prefixed with the analyzer name and belong to that
+// analyzer alone: -zerologctx.allow-funcs does not change what slogctx allows.
+//
+// The command speaks the go vet tool protocol:
+//
+//	go vet -vettool=$(which logctx) ./...
+//
+// It reads the same .zerologctx.yml policy file as the zerologctx command,
+// discovered from the working directory up to the module root.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+func main() {
+	path, err := zerologctx.FindConfig(".")
+	if err == nil && path != "" {
+		err = zerologctx.LoadConfig(path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "logctx: %v\n", err)
+		os.Exit(1)
+	}
+	multichecker.Main(zerologctx.Analyzers()...)
+}
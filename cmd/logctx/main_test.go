@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const vetSrc = `package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog/log"
+)
+
+func handle(ctx context.Context) {
+	log.Info().Msg("zerolog")
+	slog.Info("slog")
+	slog.Warn("silenced") //nolint:slogctx
+}
+`
+
+// TestVetTool builds the command and runs it through go vet -vettool against
+// a module using the zerolog stub, checking that each analyzer reports under
+// its own name and that the per-analyzer enable flags work.
+func TestVetTool(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available in PATH")
+	}
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "logctx")
+	if out, err := exec.Command("go", "build", "-o", bin, ".").CombinedOutput(); err != nil {
+		t.Fatalf("go build failed: %v\n%s", err, out)
+	}
+
+	stub := filepath.Join("..", "..", "testdata", "src", "github.com", "rs", "zerolog")
+	copyDir(t, stub, filepath.Join(dir, "zerolog"))
+	write(t, filepath.Join(dir, "zerolog", "go.mod"), "module github.com/rs/zerolog\n\ngo 1.26\n")
+	mod := filepath.Join(dir, "m")
+	write(t, filepath.Join(mod, "go.mod"), `module example.com/m
+
+go 1.26
+
+require github.com/rs/zerolog v0.0.0
+
+replace github.com/rs/zerolog => ../zerolog
+`)
+	write(t, filepath.Join(mod, "app.go"), vetSrc)
+
+	vet := func(flags ...string) string {
+		args := append([]string{"vet", "-vettool=" + bin}, flags...)
+		cmd := exec.Command("go", append(args, "./...")...)
+		cmd.Dir = mod
+		cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Fatalf("go vet %s reported nothing\n%s", strings.Join(flags, " "), out)
+		}
+		return string(out)
+	}
+
+	out := vet()
+	for _, want := range []string{"app.go:11:2: zerolog event missing .Ctx(ctx) before Msg()", "app.go:12:2: slog Info() called without context"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("go vet output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "silenced") || strings.Contains(out, "app.go:13") {
+		t.Errorf("//nolint:slogctx finding was reported:\n%s", out)
+	}
+
+	out = vet("-slogctx=false")
+	if strings.Contains(out, "slog Info()") || !strings.Contains(out, "zerolog event missing") {
+		t.Errorf("-slogctx=false: want only the zerolog finding:\n%s", out)
+	}
+	out = vet("-slogctx")
+	if strings.Contains(out, "zerolog event missing") || !strings.Contains(out, "slog Info()") {
+		t.Errorf("-slogctx: want only the slog finding:\n%s", out)
+	}
+}
+
+func copyDir(t *testing.T, src, dst string) {
+	t.Helper()
+	err := filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		write(t, filepath.Join(dst, rel), string(data))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,28 @@
+// Command slogctx is a static analysis tool that checks that log/slog calls
+// pass the context (InfoContext(ctx, ...) rather than Info(...)) when one is
+// available.
+//
+// It reads the same .zerologctx.yml policy file as the zerologctx command,
+// discovered from the working directory up to the module root.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+func main() {
+	path, err := zerologctx.FindConfig(".")
+	if err == nil && path != "" {
+		err = zerologctx.LoadConfig(path, zerologctx.SlogAnalyzer)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "slogctx: %v\n", err)
+		os.Exit(1)
+	}
+	singlechecker.Main(zerologctx.SlogAnalyzer)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// baselineVersion is the format version written to and required of baseline
+// files.
+const baselineVersion = 1
+
+// baseline is the on-disk record of accepted findings. Entries are keyed by
+// file, enclosing function, chain fingerprint and message — never by line —
+// so unrelated edits that shift code around do not invalidate them.
+type baseline struct {
+	Version  int             `json:"version"`
+	Findings []baselineEntry `json:"findings"`
+}
+
+// baselineEntry is one accepted finding; Count allows a function to contain
+// the same chain more than once.
+type baselineEntry struct {
+	File        string `json:"file"`
+	Function    string `json:"function,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+	Message     string `json:"message"`
+	Count       int    `json:"count"`
+}
+
+// findingKey is the line-independent identity of a diagnostic.
+type findingKey struct {
+	file, function, fingerprint, message string
+}
+
+// finding is a diagnostic located in the graph together with its key.
+type finding struct {
+	key  findingKey
+	act  *checker.Action
+	diag analysis.Diagnostic
+}
+
+// collectFindings returns the diagnostics of the root actions with their
+// baseline keys. A file analysed both as part of a package and of its test
+// variant yields the same diagnostic twice; it is returned once.
+func collectFindings(graph *checker.Graph) []finding {
+	type dedupKey struct {
+		posn    token.Position
+		message string
+	}
+	seen := make(map[dedupKey]bool)
+	var out []finding
+	for _, act := range graph.Roots {
+		for _, d := range act.Diagnostics {
+			k := dedupKey{act.Package.Fset.Position(d.Pos), d.Message}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			out = append(out, finding{key: keyOf(act, d), act: act, diag: d})
+		}
+	}
+	return out
+}
+
+// keyOf computes the baseline key of a diagnostic: the file relative to its
+// module root, the enclosing top-level function, and a fingerprint of the
+// normalised source of the smallest node covering the diagnostic — the
+// reported chain, an argument or a statement. Layout and comments are not
+// part of the fingerprint, so reformatting the code keeps it.
+func keyOf(act *checker.Action, d analysis.Diagnostic) findingKey {
+	fset := act.Package.Fset
+	posn := fset.Position(d.Pos)
+	key := findingKey{file: relFile(act, posn.Filename), message: d.Message}
+	end := max(d.End, d.Pos)
+
+	for _, f := range act.Package.Syntax {
+		if fset.File(f.Pos()) != fset.File(d.Pos) {
+			continue
+		}
+		key.function = enclosingFuncName(f, d.Pos)
+		var smallest ast.Node
+		ast.Inspect(f, func(n ast.Node) bool {
+			if n == nil || n.Pos() > d.Pos || n.End() < end {
+				return false
+			}
+			smallest = n
+			return true
+		})
+		if smallest != nil && smallest != f {
+			sum := sha256.Sum256([]byte(nodeString(smallest)))
+			key.fingerprint = hex.EncodeToString(sum[:8])
+		}
+		break
+	}
+	return key
+}
+
+// nodeString renders n without its layout or comments: types.ExprString for
+// an expression, the printer's output with positions dropped otherwise.
+func nodeString(n ast.Node) string {
+	if e, ok := n.(ast.Expr); ok {
+		return types.ExprString(e)
+	}
+	var b strings.Builder
+	if err := printer.Fprint(&b, token.NewFileSet(), n); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+// relFile returns filename relative to the root of the module that contains
+// it (or to the working directory outside modules), in slash form so that a
+// baseline written on one machine matches on another.
+func relFile(act *checker.Action, filename string) string {
+	root := "."
+	if m := act.Package.Module; m != nil && m.Dir != "" {
+		root = m.Dir
+	}
+	if abs, err := filepath.Abs(root); err == nil {
+		if rel, err := filepath.Rel(abs, filename); err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(filename)
+}
+
+// enclosingFuncName names the top-level declaration containing pos as Func,
+// (T).Method or (*T).Method; findings outside any function get "".
+func enclosingFuncName(f *ast.File, pos token.Pos) string {
+	for _, decl := range f.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || pos < fd.Pos() || pos >= fd.End() {
+			continue
+		}
+		if fd.Recv == nil || len(fd.Recv.List) == 0 {
+			return fd.Name.Name
+		}
+		return "(" + types.ExprString(fd.Recv.List[0].Type) + ")." + fd.Name.Name
+	}
+	return ""
+}
+
+// newBaselineFromGraph records every current finding.
+func newBaselineFromGraph(graph *checker.Graph) *baseline {
+	counts := make(map[findingKey]int)
+	for _, f := range collectFindings(graph) {
+		counts[f.key]++
+	}
+	b := &baseline{Version: baselineVersion, Findings: []baselineEntry{}}
+	for k, n := range counts {
+		b.Findings = append(b.Findings, baselineEntry{
+			File:        k.file,
+			Function:    k.function,
+			Fingerprint: k.fingerprint,
+			Message:     k.message,
+			Count:       n,
+		})
+	}
+	slices.SortFunc(b.Findings, func(x, y baselineEntry) int {
+		return cmp.Or(
+			cmp.Compare(x.File, y.File),
+			cmp.Compare(x.Function, y.Function),
+			cmp.Compare(x.Fingerprint, y.Fingerprint),
+			cmp.Compare(x.Message, y.Message),
+		)
+	})
+	return b
+}
+
+// suppress removes from the graph's root actions every diagnostic accounted
+// for by the baseline, consuming one count per occurrence so that a newly
+// added copy of a baselined chain is still reported. It returns the removed
+// findings.
+func (b *baseline) suppress(graph *checker.Graph) []finding {
+	remaining := make(map[findingKey]int, len(b.Findings))
+	for _, e := range b.Findings {
+		remaining[findingKey{e.File, e.Function, e.Fingerprint, e.Message}] += e.Count
+	}
+	type posKey struct {
+		posn    token.Position
+		message string
+	}
+	suppressed := make(map[posKey]bool)
+	var removed []finding
+	for _, f := range collectFindings(graph) {
+		if remaining[f.key] > 0 {
+			remaining[f.key]--
+			suppressed[posKey{f.act.Package.Fset.Position(f.diag.Pos), f.diag.Message}] = true
+			removed = append(removed, f)
+		}
+	}
+	for _, act := range graph.Roots {
+		act.Diagnostics = slices.DeleteFunc(act.Diagnostics, func(d analysis.Diagnostic) bool {
+			return suppressed[posKey{act.Package.Fset.Position(d.Pos), d.Message}]
+		})
+	}
+	return removed
+}
+
+func readBaseline(path string) (*baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("%s: unsupported baseline version %d (want %d)", path, b.Version, baselineVersion)
+	}
+	return &b, nil
+}
+
+func writeBaseline(path string, b *baseline) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"golang.org/x/tools/go/analysis/checker"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+// coverageRow counts the terminal calls of one package or file by status.
+type coverageRow struct {
+	Name        string  `json:"name"`
+	Calls       int     `json:"calls"`
+	WithContext int     `json:"with_context"`
+	Missing     int     `json:"missing"`
+	NoContext   int     `json:"no_context_available"`
+	Suppressed  int     `json:"suppressed"`
+	Coverage    float64 `json:"coverage"`
+
+	// Sources breaks WithContext down by where the context comes from.
+	Sources map[string]int `json:"sources,omitempty"`
+}
+
+// coverageReport is the JSON form of the coverage subcommand's output.
+type coverageReport struct {
+	By    string        `json:"by"`
+	Rows  []coverageRow `json:"rows"`
+	Total coverageRow   `json:"total"`
+}
+
+func (r *coverageRow) add(c zerologctx.TerminalCall) {
+	r.Calls++
+	switch c.Status {
+	case zerologctx.CallHasContext:
+		r.WithContext++
+		if r.Sources == nil {
+			r.Sources = make(map[string]int)
+		}
+		r.Sources[c.Source.String()]++
+	case zerologctx.CallMissingContext:
+		r.Missing++
+	case zerologctx.CallNoContext:
+		r.NoContext++
+	case zerologctx.CallSuppressed:
+		r.Suppressed++
+	}
+}
+
+// finish computes the coverage percentage: calls with context over all
+// calls. A row without calls is fully covered.
+func (r *coverageRow) finish() {
+	r.Coverage = 100
+	if r.Calls > 0 {
+		r.Coverage = 100 * float64(r.WithContext) / float64(r.Calls)
+	}
+}
+
+// runCoverage implements "zerologctx coverage": it classifies every terminal
+// call in the named packages and prints per-package (or per-file) counts.
+// With -min-coverage it exits 3 when the total coverage is below the
+// threshold, for use as a CI gate.
+func runCoverage(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("zerologctx coverage", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: zerologctx coverage [flags] packages...\n\n"+
+			"Counts the terminal calls (Msg, Msgf, MsgFunc, Send) on zerolog events and\n"+
+			"reports the share that carries a context.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.String("config", "", "path to a .zerologctx.yml or .json policy file (default: discovered from the module root)")
+	asJSON := fs.Bool("json", false, "emit JSON output")
+	by := fs.String("by", "package", "group calls by package or file")
+	minCoverage := fs.Float64("min-coverage", 0, "exit with status 3 when the total coverage percentage is below this value")
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	zerologctx.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *by != "package" && *by != "file" {
+		fmt.Fprintf(stderr, "zerologctx: unknown -by %q (want package or file)\n", *by)
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	graph, code := analyze(zerologctx.Analyzer, fs.Args(), *tests, stderr)
+	if graph == nil {
+		return code
+	}
+	report := newCoverageReport(graph, *by == "file")
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+			return 1
+		}
+	} else {
+		writeCoverageTable(stdout, report)
+	}
+	if report.Total.Coverage < *minCoverage {
+		fmt.Fprintf(stderr, "zerologctx: coverage %.1f%% is below -min-coverage=%g\n", report.Total.Coverage, *minCoverage)
+		return max(code, 3)
+	}
+	return code
+}
+
+// newCoverageReport aggregates the calls recorded in the root actions'
+// results. A file analysed both as part of a package and of its test variant
+// is counted once, under the package's own path.
+func newCoverageReport(graph *checker.Graph, byFile bool) *coverageReport {
+	report := &coverageReport{By: "package", Rows: []coverageRow{}, Total: coverageRow{Name: "total"}}
+	if byFile {
+		report.By = "file"
+	}
+	rows := make(map[string]*coverageRow)
+	seen := make(map[string]bool) // "file:offset" of counted calls
+	for _, act := range graph.Roots {
+		res, ok := act.Result.(*zerologctx.Result)
+		if !ok {
+			continue
+		}
+		for _, c := range res.Calls {
+			posn := act.Package.Fset.Position(c.Pos)
+			key := fmt.Sprintf("%s:%d", posn.Filename, posn.Offset)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			name := act.Package.PkgPath
+			if byFile {
+				name = relFile(act, posn.Filename)
+			}
+			row, ok := rows[name]
+			if !ok {
+				row = &coverageRow{Name: name}
+				rows[name] = row
+			}
+			row.add(c)
+			report.Total.add(c)
+		}
+	}
+	for _, row := range rows {
+		row.finish()
+		report.Rows = append(report.Rows, *row)
+	}
+	slices.SortFunc(report.Rows, func(x, y coverageRow) int { return cmp.Compare(x.Name, y.Name) })
+	report.Total.finish()
+	return report
+}
+
+func writeCoverageTable(w io.Writer, report *coverageReport) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	header := "PACKAGE"
+	if report.By == "file" {
+		header = "FILE"
+	}
+	fmt.Fprintf(tw, "%s\tCALLS\tWITH CTX\tMISSING\tNO CTX\tSUPPRESSED\tCOVERAGE\t\n", header)
+	for _, row := range append(report.Rows, report.Total) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%.1f%%\t\n",
+			row.Name, row.Calls, row.WithContext, row.Missing, row.NoContext, row.Suppressed, row.Coverage)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const coverageSrc = `package app
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func handle(ctx context.Context) {
+	log.Info().Ctx(ctx).Msg("inline")
+	l := log.With().Ctx(ctx).Logger()
+	l.Info().Msg("contextual logger")
+	log.Info().Msg("missing")
+	log.Info().Msg("silenced") //nolint:zerologctx
+}
+
+func startup() {
+	log.Info().Msg("no context here")
+}
+`
+
+// TestCoverage checks the per-file counts, the JSON report and the
+// -min-coverage gate of the coverage subcommand.
+func TestCoverage(t *testing.T) {
+	writeTestModule(t, map[string]string{"app/app.go": coverageSrc})
+
+	var stdout, stderr bytes.Buffer
+	code := runCoverage([]string{"-json", "-by=file", "./..."}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("exit code = %d:\n%s", code, stderr.String())
+	}
+	var report coverageReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	want := coverageRow{Name: "app/app.go", Calls: 5, WithContext: 2, Missing: 1, NoContext: 1, Suppressed: 1, Coverage: 40}
+	if len(report.Rows) != 1 {
+		t.Fatalf("got %d rows, want 1:\n%s", len(report.Rows), stdout.String())
+	}
+	got := report.Rows[0]
+	if got.Sources["inline-ctx"] != 1 || got.Sources["contextual-logger"] != 1 {
+		t.Errorf("sources = %v, want one inline-ctx and one contextual-logger", got.Sources)
+	}
+	got.Sources = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("row = %+v, want %+v", got, want)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if code := runCoverage([]string{"-min-coverage=95", "./..."}, &stdout, &stderr); code != 3 {
+		t.Errorf("-min-coverage=95 exit code = %d, want 3:\n%s", code, stderr.String())
+	}
+	for _, want := range []string{"PACKAGE", "example.com/m/app", "40.0%"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("table lacks %q:\n%s", want, stdout.String())
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// changedLines maps canonical file names (see canonicalPath) to the set of
+// new-side line numbers touched by a diff. A file mapped to nil changed
+// entirely (it is new).
+type changedLines map[string]map[int]bool
+
+// touches reports whether the line range [from, to] of filename intersects
+// the changed lines.
+func (c changedLines) touches(filename string, from, to int) bool {
+	lines, ok := c[filename]
+	if !ok {
+		return false
+	}
+	if lines == nil {
+		return true
+	}
+	for l := from; l <= to; l++ {
+		if lines[l] {
+			return true
+		}
+	}
+	return false
+}
+
+// gitChangedLines computes the lines changed in the working tree relative to
+// rev with a local `git diff`, plus untracked files, which count as changed
+// in full. Paths are resolved against the repository's top level.
+func gitChangedLines(rev string) (changedLines, error) {
+	top, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root := strings.TrimSpace(string(top))
+	out, err := git("-C", root, "diff", "-U0", "--no-color", "--no-ext-diff", rev, "--")
+	if err != nil {
+		return nil, err
+	}
+	changed, err := parseUnifiedDiff(bytes.NewReader(out), root)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git("-C", root, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	for name := range strings.SplitSeq(string(untracked), "\x00") {
+		if name != "" {
+			changed[canonicalPath(filepath.Join(root, filepath.FromSlash(name)))] = nil
+		}
+	}
+	return changed, nil
+}
+
+// fileChangedLines reads a unified diff from path. Its file names are
+// resolved against the repository top level when the working directory is
+// inside a git repository, and against the working directory otherwise.
+func fileChangedLines(path string) (changedLines, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	root := "."
+	if top, err := git("rev-parse", "--show-toplevel"); err == nil {
+		root = strings.TrimSpace(string(top))
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := parseUnifiedDiff(f, root)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return changed, nil
+}
+
+func git(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// parseUnifiedDiff collects the new-side lines added or modified by a
+// unified diff, with or without context lines. A deletion that is not
+// replaced by added lines marks the new line just before it, so a chain that
+// lost a line is still considered changed. File names are taken from "+++" headers, with git's "b/" prefix
+// removed, and joined to root.
+func parseUnifiedDiff(r io.Reader, root string) (changedLines, error) {
+	changed := make(changedLines)
+	var lines map[int]bool // nil for deleted files
+	next := 0              // new-side number of the next context or added line
+	oldLeft, newLeft := 0, 0
+	deleted := false // a deletion not (yet) replaced by added lines
+	flushDeletion := func() {
+		if deleted && lines != nil && next > 1 {
+			lines[next-1] = true
+		}
+		deleted = false
+	}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		text := sc.Text()
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if lines != nil {
+					lines[next] = true
+				}
+				deleted = false
+				next++
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				deleted = true
+				oldLeft--
+			case strings.HasPrefix(text, `\`):
+				// "\ No newline at end of file"
+			default:
+				flushDeletion()
+				next++
+				oldLeft--
+				newLeft--
+			}
+			if oldLeft <= 0 && newLeft <= 0 {
+				flushDeletion()
+			}
+			continue
+		}
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			name := strings.TrimSpace(strings.TrimPrefix(text, "+++ "))
+			if tab := strings.IndexByte(name, '\t'); tab >= 0 {
+				name = name[:tab] // timestamp suffix of non-git diffs
+			}
+			if name == "/dev/null" {
+				lines = nil
+				continue
+			}
+			name = strings.TrimPrefix(name, "b/")
+			lines = make(map[int]bool)
+			changed[canonicalPath(filepath.Join(root, filepath.FromSlash(name)))] = lines
+		case strings.HasPrefix(text, "@@ "):
+			var err error
+			if oldLeft, next, newLeft, err = parseHunkHeader(text); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			if newLeft == 0 {
+				next++ // a pure deletion's start is the line before it
+			}
+		}
+	}
+	return changed, sc.Err()
+}
+
+// parseHunkHeader extracts the old-side count and the new-side start and
+// count from a hunk header such as "@@ -12,3 +14,5 @@ func f() {". An
+// omitted count means 1.
+func parseHunkHeader(header string) (oldCount, newStart, newCount int, err error) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", header)
+	}
+	_, oldCount, err1 := parseRange(fields[1][1:])
+	newStart, newCount, err2 := parseRange(fields[2][1:])
+	if err1 != nil || err2 != nil {
+		return 0, 0, 0, fmt.Errorf("malformed hunk header %q", header)
+	}
+	return oldCount, newStart, newCount, nil
+}
+
+// parseRange parses a hunk range "start[,count]".
+func parseRange(text string) (start, count int, err error) {
+	startText, countText, hasCount := strings.Cut(text, ",")
+	if start, err = strconv.Atoi(startText); err != nil {
+		return 0, 0, err
+	}
+	count = 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return 0, 0, err
+		}
+	}
+	return start, count, nil
+}
+
+// canonicalPath makes a file name absolute and resolves symlinks, so that
+// names from git (which reports the resolved top level) and from the loader
+// compare equal.
+func canonicalPath(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		name = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(name); err == nil {
+		name = resolved
+	}
+	return name
+}
+
+// keeps reports whether the diagnostic's line range (Pos through End)
+// touches a changed line.
+func (c changedLines) keeps(fset *token.FileSet, d analysis.Diagnostic) bool {
+	from := fset.Position(d.Pos)
+	to := from
+	if d.End.IsValid() {
+		to = fset.Position(d.End)
+	}
+	return c.touches(canonicalPath(from.Filename), from.Line, to.Line)
+}
+
+// filterChanged removes from the graph's root actions every diagnostic that
+// does not touch a changed line.
+func filterChanged(graph *checker.Graph, changed changedLines) {
+	for _, act := range graph.Roots {
+		act.Diagnostics = slices.DeleteFunc(act.Diagnostics, func(d analysis.Diagnostic) bool {
+			return !changed.keeps(act.Package.Fset, d)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseUnifiedDiff pins the changed-line computation for git -U0 output,
+// context diffs, pure deletions, deleted-line content that looks like a file
+// header, and deleted files.
+func TestParseUnifiedDiff(t *testing.T) {
+	const diff = `diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -3,0 +4,2 @@ func f() {
++	added1
++	added2
+@@ -10 +11,0 @@ func g() {
+--- removed line that looks like a header
+diff --git a/b.go b/b.go
+--- a/b.go
++++ b/b.go
+@@ -1,4 +1,4 @@
+ package b
+-old
++new
+
+ keep
+\ No newline at end of file
+diff --git a/gone.go b/gone.go
+--- a/gone.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package gone
+`
+	root := t.TempDir()
+	got, err := parseUnifiedDiff(strings.NewReader(diff), root)
+	if err != nil {
+		t.Fatalf("parseUnifiedDiff: %v", err)
+	}
+	want := changedLines{
+		canonicalPath(filepath.Join(root, "a.go")): {4: true, 5: true, 11: true},
+		canonicalPath(filepath.Join(root, "b.go")): {2: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseUnifiedDiff = %v, want %v", got, want)
+	}
+
+	if _, err := parseUnifiedDiff(strings.NewReader("+++ b/x.go\n@@ bogus @@\n"), root); err == nil {
+		t.Error("parseUnifiedDiff accepted a malformed hunk header")
+	}
+}
+
+// TestDiffBase runs the driver with -diff-base against a real git repository:
+// only findings whose chain touches a changed line, or that live in an
+// untracked file, are reported.
+func TestDiffBase(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available in PATH")
+	}
+	const src = `package app
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func handle(ctx context.Context) {
+	log.Info().Msg("unchanged")
+	log.Info().
+		Msg("chain end edited")
+}
+`
+	mod := writeTestModule(t, map[string]string{"app/app.go": src})
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = mod
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	gitRun("init", "-q")
+	gitRun("add", "-A")
+	gitRun("commit", "-q", "-m", "base")
+
+	write(t, filepath.Join(mod, "app", "app.go"), strings.Replace(src, `Msg("chain end edited")`, `Msg("chain end edited!")`, 1))
+	write(t, filepath.Join(mod, "app", "new.go"), `package app
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func fresh(ctx context.Context) { log.Info().Msg("untracked file") }
+`)
+
+	code, out := runCmd(t, "-diff-base", "HEAD", "./...")
+	if code != 3 {
+		t.Errorf("exit code = %d, want 3:\n%s", code, out)
+	}
+	for _, want := range []string{"app.go:11:", "new.go:9:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks finding at %s:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "app.go:10:") {
+		t.Errorf("unchanged line reported:\n%s", out)
+	}
+}
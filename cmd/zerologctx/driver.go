@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+// driverFlags are the flags singlechecker does not know about. Their
+// presence on the command line selects the built-in driver (runDriver)
+// instead of singlechecker.Main.
+var driverFlags = []string{"baseline", "write-baseline", "diff-base", "diff-file", "format"}
+
+// driverOptions holds the flags of the built-in driver.
+type driverOptions struct {
+	json          bool
+	format        string
+	tests         bool
+	baseline      string
+	writeBaseline string
+	diffBase      string
+	diffFile      string
+}
+
+// newDriverFlagSet returns a FlagSet carrying the driver's own flags, -config
+// (already applied by main) and the analyzer's flags, so that a single
+// command line configures all three.
+func newDriverFlagSet(opts *driverOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet("zerologctx", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: zerologctx [flags] packages...\n\n%s\n\nFlags:\n", zerologctx.Analyzer.Doc)
+		fs.PrintDefaults()
+	}
+	fs.String("config", "", "path to a .zerologctx.yml or .json policy file (default: discovered from the module root)")
+	fs.BoolVar(&opts.json, "json", false, "emit JSON output (same as -format=json)")
+	fs.StringVar(&opts.format, "format", "text", "output format: text, json or sarif")
+	fs.BoolVar(&opts.tests, "test", true, "indicates whether test files should be analyzed, too")
+	fs.StringVar(&opts.baseline, "baseline", "", "suppress the findings recorded in this baseline file")
+	fs.StringVar(&opts.writeBaseline, "write-baseline", "", "record the current findings in this baseline file and exit")
+	fs.StringVar(&opts.diffBase, "diff-base", "", "report only findings on lines changed relative to this git revision")
+	fs.StringVar(&opts.diffFile, "diff-file", "", "report only findings on lines changed by this unified diff")
+	zerologctx.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	return fs
+}
+
+// runDriver analyses the packages named on the command line with a
+// go/analysis/checker graph, so that diagnostics can be post-processed
+// (baseline suppression, changed-line filtering) before they are printed. Exit codes follow
+// singlechecker: 1 for errors, 3 when text output reported diagnostics.
+func runDriver(args []string, stdout, stderr io.Writer) int {
+	var opts driverOptions
+	fs := newDriverFlagSet(&opts)
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	if opts.json {
+		opts.format = "json"
+	}
+	switch opts.format {
+	case "text", "json", "sarif":
+	default:
+		fmt.Fprintf(stderr, "zerologctx: unknown -format %q (want text, json or sarif)\n", opts.format)
+		return 2
+	}
+	if opts.diffBase != "" && opts.diffFile != "" {
+		fmt.Fprintln(stderr, "zerologctx: -diff-base and -diff-file are mutually exclusive")
+		return 2
+	}
+
+	graph, code := analyze(zerologctx.Analyzer, fs.Args(), opts.tests, stderr)
+	if graph == nil {
+		return code
+	}
+
+	if opts.writeBaseline != "" {
+		if err := writeBaseline(opts.writeBaseline, newBaselineFromGraph(graph)); err != nil {
+			fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+			return 1
+		}
+		return code
+	}
+	var baselined []finding
+	if opts.baseline != "" {
+		b, err := readBaseline(opts.baseline)
+		if err != nil {
+			fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+			return 1
+		}
+		baselined = b.suppress(graph)
+	}
+	var keep func(*token.FileSet, analysis.Diagnostic) bool
+	if opts.diffBase != "" || opts.diffFile != "" {
+		var changed changedLines
+		var err error
+		if opts.diffBase != "" {
+			changed, err = gitChangedLines(opts.diffBase)
+		} else {
+			changed, err = fileChangedLines(opts.diffFile)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+			return 1
+		}
+		filterChanged(graph, changed)
+		keep = changed.keeps
+	}
+
+	// Structured output always exits 0 on success, as with singlechecker's
+	// -json: the findings are in the document, not the exit code.
+	switch opts.format {
+	case "sarif":
+		if err := writeSARIF(stdout, sarifFindings(graph, baselined, keep)); err != nil {
+			fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+			return 1
+		}
+		return code
+	case "json":
+		if err := graph.PrintJSON(stdout); err != nil {
+			fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+			return 1
+		}
+		return code
+	}
+	if err := graph.PrintText(stderr, -1); err != nil {
+		fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+		return 1
+	}
+	for act := range graph.All() {
+		if act.Err != nil {
+			return 1
+		}
+	}
+	for _, act := range graph.Roots {
+		if len(act.Diagnostics) > 0 {
+			return max(code, 3)
+		}
+	}
+	return code
+}
+
+// analyze loads patterns and runs a over them. It returns a nil
+// graph when loading or analysis failed outright; otherwise code is 1 if some
+// packages had errors (which are printed), and 0 if none did.
+func analyze(a *analysis.Analyzer, patterns []string, tests bool, stderr io.Writer) (*checker.Graph, int) {
+	cfg := &packages.Config{
+		Mode:  packages.LoadAllSyntax,
+		Tests: tests,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+		return nil, 1
+	}
+	code := 0
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, err := range p.Errors {
+			fmt.Fprintln(stderr, err)
+			code = 1
+		}
+	})
+	graph, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+		return nil, 1
+	}
+	return graph, code
+}
+
+// hasDriverFlag reports whether args set any of driverFlags.
+func hasDriverFlag(args []string) bool {
+	for _, name := range driverFlags {
+		if _, ok := flagValue(args, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// flagValue extracts the value of -name (or --name) from args, accepting both
+// the "-name=value" and "-name value" forms. Package patterns never start
+// with "-", so the whole argument list up to "--" is scanned.
+func flagValue(args []string, name string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		key, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || key != name {
+			continue
+		}
+		if hasValue {
+			return value, true
+		}
+		if i+1 < len(args) {
+			return args[i+1], true
+		}
+		return "", true
+	}
+	return "", false
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+// writeTestModule creates a module in a temporary directory whose
+// github.com/rs/zerolog dependency is replaced by a copy of the testdata
+// stub, so the built-in driver can load it offline. files maps slash paths
+// relative to the module root to their contents. The test's working
+// directory is changed to the module root.
+func writeTestModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	stub := filepath.Join("..", "..", "testdata", "src", "github.com", "rs", "zerolog")
+	copyDir(t, stub, filepath.Join(dir, "zerolog"))
+	write(t, filepath.Join(dir, "zerolog", "go.mod"), "module github.com/rs/zerolog\n\ngo 1.26\n")
+
+	mod := filepath.Join(dir, "m")
+	write(t, filepath.Join(mod, "go.mod"), `module example.com/m
+
+go 1.26
+
+require github.com/rs/zerolog v0.0.0
+
+replace github.com/rs/zerolog => ../zerolog
+`)
+	for name, content := range files {
+		write(t, filepath.Join(mod, filepath.FromSlash(name)), content)
+	}
+	t.Chdir(mod)
+	return mod
+}
+
+func copyDir(t *testing.T, src, dst string) {
+	t.Helper()
+	err := filepath.WalkDir(src, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		write(t, filepath.Join(dst, rel), string(data))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// runCmd runs the built-in driver in-process and returns its exit code and
+// combined output.
+func runCmd(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	var out bytes.Buffer
+	code := runDriver(args, &out, &out)
+	return code, out.String()
+}
+
+const baselineSrc = `package app
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func handle(ctx context.Context) {
+	log.Info().Msg("old finding")
+}
+`
+
+// TestBaseline verifies the -write-baseline / -baseline round trip: recorded
+// findings stay suppressed after unrelated edits shift their lines, while a
+// new finding — including another copy of a baselined chain — is reported.
+func TestBaseline(t *testing.T) {
+	mod := writeTestModule(t, map[string]string{"app/app.go": baselineSrc})
+
+	if code, out := runCmd(t, "-write-baseline", "baseline.json", "./..."); code != 0 {
+		t.Fatalf("-write-baseline exited %d:\n%s", code, out)
+	}
+	data, err := os.ReadFile(filepath.Join(mod, "baseline.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"file": "app/app.go"`, `"function": "handle"`, `"count": 1`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("baseline does not contain %s:\n%s", want, data)
+		}
+	}
+
+	// Shift the old finding down and reformat it: still suppressed.
+	shifted := strings.Replace(baselineSrc, "func handle(ctx context.Context) {\n\tlog.Info().Msg(\"old finding\")",
+		"// handle has a new doc comment.\nfunc handle(ctx context.Context) {\n\t_ = 1\n\tlog.Info().\n\t\tMsg(\"old finding\")", 1)
+	write(t, filepath.Join(mod, "app", "app.go"), shifted)
+	if code, out := runCmd(t, "-baseline", "baseline.json", "./..."); code != 0 || out != "" {
+		t.Errorf("baselined finding reported after line shift (exit %d):\n%s", code, out)
+	}
+
+	// A second copy of the same chain and a new chain are both reported.
+	grown := strings.Replace(shifted, "Msg(\"old finding\")",
+		"Msg(\"old finding\")\n\tlog.Info().Msg(\"old finding\")\n\tlog.Warn().Msg(\"new finding\")", 1)
+	write(t, filepath.Join(mod, "app", "app.go"), grown)
+	code, out := runCmd(t, "-baseline", "baseline.json", "./...")
+	if code != 3 {
+		t.Errorf("exit code = %d, want 3:\n%s", code, out)
+	}
+	if n := strings.Count(out, "missing .Ctx(ctx)"); n != 2 {
+		t.Errorf("got %d findings, want 2 (extra copy and new chain):\n%s", n, out)
+	}
+
+	// A finding on a statement — here globalctxlogger's, an analyzer the
+	// command does not run — is fingerprinted by the statement: another one
+	// with the same message in the same function has another key.
+	keyOfGlobal := func(assign string) findingKey {
+		t.Helper()
+		write(t, filepath.Join(mod, "app", "app.go"), strings.Replace(baselineSrc, `log.Info().Msg("old finding")`, assign, 1))
+		graph, code := analyze(zerologctx.GlobalCtxLoggerAnalyzer, []string{"./..."}, true, io.Discard)
+		if graph == nil || code != 0 {
+			t.Fatalf("analyzing %s failed (exit %d)", assign, code)
+		}
+		findings := collectFindings(graph)
+		if len(findings) != 1 {
+			t.Fatalf("got %d globalctxlogger findings for %s, want 1", len(findings), assign)
+		}
+		return findings[0].key
+	}
+	old := keyOfGlobal("log.Logger = log.With().Ctx(ctx).Logger()")
+	replaced := keyOfGlobal(`log.Logger = log.With().Ctx(ctx).Str("k", "v").Logger()`)
+	if old.fingerprint == "" || old == replaced {
+		t.Errorf("statement findings keyed %+v and %+v, want distinct fingerprints", old, replaced)
+	}
+}
+
+// TestFormatSARIF checks the SARIF document: rule metadata from the
+// analyzer's Doc, a region spanning the whole chain in UTF-16 columns, the
+// suggested fix, and an in-source suppression for a nolint'd finding.
+func TestFormatSARIF(t *testing.T) {
+	writeTestModule(t, map[string]string{"app/app.go": `package app
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func handle(ctx context.Context) {
+	/* é */ log.Info().Msg("reported")
+	log.Info().Msg("silenced") //nolint:zerologctx // startup noise
+}
+`})
+	code, out := runCmd(t, "-format=sarif", "./...")
+	if code != 0 {
+		t.Fatalf("exit code = %d:\n%s", code, out)
+	}
+	var doc sarifLog
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v\n%s", err, out)
+	}
+	if len(doc.Runs) != 1 || len(doc.Runs[0].Tool.Driver.Rules) != 2 {
+		t.Fatalf("want one run with two rules:\n%s", out)
+	}
+	for i, id := range []string{"zerologctx/missing-ctx", "zerologctx/nil-ctx"} {
+		if rule := doc.Runs[0].Tool.Driver.Rules[i]; rule.ID != id || rule.Help.Text != zerologctx.Analyzer.Doc {
+			t.Errorf("rule %d = %+v, want id %s with the analyzer Doc as help", i, rule, id)
+		}
+	}
+	results := doc.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2:\n%s", len(results), out)
+	}
+
+	reported, silenced := results[0], results[1]
+	if reported.RuleID != "zerologctx/missing-ctx" || silenced.RuleID != "zerologctx/missing-ctx" {
+		t.Errorf("rule ids = %q, %q, want zerologctx/missing-ctx", reported.RuleID, silenced.RuleID)
+	}
+	loc := reported.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "app/app.go" {
+		t.Errorf("uri = %q, want app/app.go", loc.ArtifactLocation.URI)
+	}
+	// "\t/* é */ " is 9 UTF-16 units (10 bytes); the chain spans 26 units.
+	if want := (sarifRegion{StartLine: 10, StartColumn: 10, EndLine: 10, EndColumn: 36}); loc.Region != want {
+		t.Errorf("region = %+v, want %+v", loc.Region, want)
+	}
+	if len(reported.Fixes) != 1 || reported.Fixes[0].ArtifactChanges[0].Replacements[0].InsertedContent.Text != "Ctx(ctx)." {
+		t.Errorf("fixes = %+v, want one inserting Ctx(ctx).", reported.Fixes)
+	}
+	if len(reported.Suppressions) != 0 {
+		t.Errorf("reported finding has suppressions %+v", reported.Suppressions)
+	}
+	want := sarifSuppression{Kind: "inSource", Justification: "//nolint:zerologctx // startup noise"}
+	if len(silenced.Suppressions) != 1 || silenced.Suppressions[0] != want {
+		t.Errorf("suppressions = %+v, want [%+v]", silenced.Suppressions, want)
+	}
+}
+
+// TestFormatSARIFAllowFuncs checks that a finding silenced by -allow-funcs
+// is kept in the SARIF output with an external suppression, as a nolint'd
+// one is with an in-source suppression.
+func TestFormatSARIFAllowFuncs(t *testing.T) {
+	writeTestModule(t, map[string]string{"app/app.go": baselineSrc})
+	t.Cleanup(func() { zerologctx.Analyzer.Flags.Set("allow-funcs", "") })
+	code, out := runCmd(t, "-format=sarif", "-allow-funcs=example.com/m/app.handle", "./...")
+	if code != 0 {
+		t.Fatalf("exit code = %d:\n%s", code, out)
+	}
+	var doc sarifLog
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v\n%s", err, out)
+	}
+	results := doc.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1:\n%s", len(results), out)
+	}
+	want := sarifSuppression{Kind: "external", Justification: zerologctx.AllowFuncsDirective}
+	if sup := results[0].Suppressions; len(sup) != 1 || sup[0] != want {
+		t.Errorf("suppressions = %+v, want [%+v]", sup, want)
+	}
+}
+
+// TestFormatJSON verifies that -json output carries each finding's stable
+// category next to its message.
+func TestFormatJSON(t *testing.T) {
+	writeTestModule(t, map[string]string{"app/app.go": baselineSrc})
+	code, out := runCmd(t, "-json", "./...")
+	if code != 0 {
+		t.Fatalf("exit code = %d:\n%s", code, out)
+	}
+	var doc map[string]map[string][]struct {
+		Category string `json:"category"`
+		Message  string `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	diags := doc["example.com/m/app"]["zerologctx"]
+	if len(diags) != 1 || diags[0].Category != zerologctx.CategoryMissingCtx {
+		t.Errorf("diagnostics = %+v, want one with category %s", diags, zerologctx.CategoryMissingCtx)
+	}
+}
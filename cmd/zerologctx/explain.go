@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+// runExplain implements "zerologctx explain file.go:LINE": it analyses the
+// package containing file with -explain and prints, for each terminal call
+// on LINE, how it was classified and the reasoning that led there.
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("zerologctx explain", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: zerologctx explain [flags] file.go:LINE\n\n"+
+			"Explains why the terminal calls on a line were or were not reported.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.String("config", "", "path to a .zerologctx.yml or .json policy file (default: discovered from the module root)")
+	zerologctx.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	file, line, err := parseFileLine(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+		return 2
+	}
+	if err := fs.Set("explain", "true"); err != nil {
+		fmt.Fprintf(stderr, "zerologctx: %v\n", err)
+		return 1
+	}
+
+	graph, code := analyze(zerologctx.Analyzer, []string{"file=" + file}, strings.HasSuffix(file, "_test.go"), stderr)
+	if graph == nil {
+		return code
+	}
+	want := canonicalPath(file)
+	seen := make(map[token.Position]bool)
+	found := false
+	for _, act := range graph.Roots {
+		res, ok := act.Result.(*zerologctx.Result)
+		if !ok {
+			continue
+		}
+		fset := act.Package.Fset
+		for _, c := range res.Calls {
+			posn := fset.Position(c.Pos)
+			if posn.Line != line || canonicalPath(posn.Filename) != want || seen[posn] {
+				continue
+			}
+			seen[posn] = true
+			found = true
+			fmt.Fprintf(stdout, "%s: %s(): %s\n", displayPosition(posn), c.Method, describeCall(c))
+			for _, step := range c.Explain {
+				fmt.Fprintf(stdout, "\t%s: %s\n", displayPosition(fset.Position(step.Pos)), step.Message)
+			}
+		}
+	}
+	if !found {
+		fmt.Fprintf(stderr, "zerologctx: no zerolog terminal call (Msg, Msgf, MsgFunc, Send) on %s:%d\n", file, line)
+		return max(code, 1)
+	}
+	return code
+}
+
+// parseFileLine splits a "file.go:LINE" argument.
+func parseFileLine(arg string) (string, int, error) {
+	i := strings.LastIndexByte(arg, ':')
+	if i < 0 {
+		return "", 0, fmt.Errorf("want file.go:LINE, got %q", arg)
+	}
+	line, err := strconv.Atoi(arg[i+1:])
+	if err != nil || line < 1 {
+		return "", 0, fmt.Errorf("invalid line number in %q", arg)
+	}
+	if _, err := os.Stat(arg[:i]); err != nil {
+		return "", 0, err
+	}
+	return arg[:i], line, nil
+}
+
+// describeCall summarises a call's classification.
+func describeCall(c zerologctx.TerminalCall) string {
+	switch c.Status {
+	case zerologctx.CallHasContext:
+		return "has context (" + c.Source.String() + ") - not reported"
+	case zerologctx.CallMissingContext:
+		return "missing context - reported"
+	case zerologctx.CallNoContext:
+		return "missing context, but none is available - not reported"
+	case zerologctx.CallSuppressed:
+		return "missing context - suppressed"
+	}
+	return c.Status.String()
+}
+
+// displayPosition prints posn with its file name relative to the working
+// directory when it lies below it.
+func displayPosition(posn token.Position) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, posn.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+			posn.Filename = rel
+		}
+	}
+	return posn.String()
+}
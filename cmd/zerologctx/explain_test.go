@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+// TestExplain runs the explain subcommand on a reported and an accepted call
+// and checks that the deciding steps are printed.
+func TestExplain(t *testing.T) {
+	writeTestModule(t, map[string]string{"app/app.go": `package app
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func handle(ctx context.Context) {
+	l := log.With().Ctx(ctx).Logger()
+	l.Info().Msg("accepted")
+	log.Info().Msg("reported")
+}
+`})
+	explainFlag := zerologctx.Analyzer.Flags.Lookup("explain")
+	t.Cleanup(func() { explainFlag.Value.Set("false") })
+
+	for _, tc := range []struct {
+		arg  string
+		want []string
+	}{
+		{"app/app.go:11", []string{
+			"app/app.go:11:2: Msg(): has context (contextual-logger) - not reported",
+			"\tapp/app.go:10:2: l holds a logger with context as of this assignment",
+		}},
+		{"app/app.go:12", []string{
+			"app/app.go:12:2: Msg(): missing context - reported",
+			"\tapp/app.go:12:2: Event created by log.Info(), which does not attach a context",
+			"\tapp/app.go:9:13: context candidate ctx chosen (the name ctx is preferred)",
+		}},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := runExplain([]string{tc.arg}, &stdout, &stderr); code != 0 {
+			t.Fatalf("explain %s exited %d:\n%s", tc.arg, code, stderr.String())
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(stdout.String(), want+"\n") {
+				t.Errorf("explain %s output lacks %q:\n%s", tc.arg, want, stdout.String())
+			}
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runExplain([]string{"app/app.go:3"}, &stdout, &stderr); code != 1 {
+		t.Errorf("explain on a line without calls exited %d, want 1", code)
+	}
+}
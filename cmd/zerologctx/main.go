@@ -1,14 +1,70 @@
 // Command zerologctx is a static analysis tool that checks
 // that zerolog logging events include context via the Ctx() method.
+//
+// Settings can be kept in a policy file shared with the golangci-lint plugin:
+// -config path/to/.zerologctx.yml, or a .zerologctx.yml found in the working
+// directory or one of its parents up to the module root. Flags given on the
+// command line override the file.
+//
+// "zerologctx coverage [-by=file] [-json] [-min-coverage=N] packages..."
+// reports the share of terminal calls that carry a context instead of
+// listing findings, and "zerologctx explain file.go:LINE" prints why the
+// calls on a line were or were not reported (-explain attaches the same
+// reasoning to every finding as related information).
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+
 	"golang.org/x/tools/go/analysis/singlechecker"
 
 	"github.com/tolmachov/zerologctx"
 )
 
 func main() {
+	// Registered so that singlechecker's flag parsing accepts it and -h lists
+	// it; the value itself is read by applyConfig before parsing.
+	flag.String("config", "", "path to a .zerologctx.yml or .json policy file (default: discovered from the module root)")
+	if err := applyConfig(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "zerologctx: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "coverage":
+			os.Exit(runCoverage(os.Args[2:], os.Stdout, os.Stderr))
+		case "explain":
+			os.Exit(runExplain(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
+	// The built-in driver handles the flags that need to post-process
+	// diagnostics; everything else goes through singlechecker, which keeps
+	// -fix, -diff and the other standard analysis flags.
+	if hasDriverFlag(os.Args[1:]) {
+		os.Exit(runDriver(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	// singlechecker runs a single analyzer as a command line tool
 	singlechecker.Main(zerologctx.Analyzer)
 }
+
+// applyConfig loads the policy file named by -config, or the one discovered
+// from the working directory, into the analyzer's flags. It runs before the
+// command line is parsed so that explicit flags take precedence.
+func applyConfig(args []string) error {
+	path, ok := flagValue(args, "config")
+	if !ok {
+		var err error
+		if path, err = zerologctx.FindConfig("."); err != nil {
+			return err
+		}
+	}
+	if path == "" {
+		return nil
+	}
+	return zerologctx.LoadConfig(path, zerologctx.Analyzer)
+}
@@ -46,3 +46,29 @@ func TestMainHelp(t *testing.T) {
 		t.Errorf("binary -h output did not mention zerologctx: %s", out)
 	}
 }
+
+// TestFlagValue pins the pre-parse extraction of -config and the driver
+// flags, which must happen before singlechecker parses the command line.
+func TestFlagValue(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		want   string
+		wantOK bool
+	}{
+		{[]string{"./..."}, "", false},
+		{[]string{"-config", "a.yml", "./..."}, "a.yml", true},
+		{[]string{"-config=a.yml", "./..."}, "a.yml", true},
+		{[]string{"--config=a.yml", "./..."}, "a.yml", true},
+		{[]string{"-allow-funcs", "x", "-config", "b.json", "./..."}, "b.json", true},
+		{[]string{"-config=", "./..."}, "", true}, // explicitly disables discovery
+		{[]string{"--", "-config", "a.yml"}, "", false},
+		{[]string{"-configx=a.yml"}, "", false},
+		{[]string{"./...", "-config"}, "", true},
+	}
+	for _, tc := range testCases {
+		got, ok := flagValue(tc.args, "config")
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("flagValue(%q, \"config\") = %q, %v; want %q, %v", tc.args, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+// The subset of the SARIF 2.1.0 object model the command emits.
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+		FullDescription  sarifMessage `json:"fullDescription"`
+		Help             sarifMessage `json:"help"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID       string             `json:"ruleId"`
+		Level        string             `json:"level"`
+		Message      sarifMessage       `json:"message"`
+		Locations    []sarifLocation    `json:"locations"`
+		Fixes        []sarifFix         `json:"fixes,omitempty"`
+		Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           sarifRegion           `json:"region"`
+	}
+	sarifArtifactLocation struct {
+		URI       string `json:"uri"`
+		URIBaseID string `json:"uriBaseId,omitempty"`
+	}
+	// sarifRegion columns count UTF-16 code units, SARIF's default
+	// columnKind.
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+		EndLine     int `json:"endLine"`
+		EndColumn   int `json:"endColumn"`
+	}
+	sarifFix struct {
+		Description     sarifMessage          `json:"description"`
+		ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+	}
+	sarifArtifactChange struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Replacements     []sarifReplacement    `json:"replacements"`
+	}
+	sarifReplacement struct {
+		DeletedRegion   sarifRegion  `json:"deletedRegion"`
+		InsertedContent sarifMessage `json:"insertedContent"`
+	}
+	sarifSuppression struct {
+		Kind          string `json:"kind"`
+		Justification string `json:"justification,omitempty"`
+	}
+)
+
+// sarifFinding is a diagnostic to emit, with the suppression that silenced
+// it, if any.
+type sarifFinding struct {
+	fset        *token.FileSet
+	diag        analysis.Diagnostic
+	suppression *sarifSuppression
+}
+
+// sarifFindings gathers the reportable diagnostics left in the graph, the
+// findings silenced in source by //nolint and externally by -allow-funcs
+// (from the analyzer's Result), and those silenced externally by the
+// baseline. keep, when non-nil, drops
+// findings outside the changed lines.
+func sarifFindings(graph *checker.Graph, baselined []finding, keep func(*token.FileSet, analysis.Diagnostic) bool) []sarifFinding {
+	type dedupKey struct {
+		posn    token.Position
+		message string
+	}
+	seen := make(map[dedupKey]bool)
+	var out []sarifFinding
+	add := func(fset *token.FileSet, d analysis.Diagnostic, sup *sarifSuppression) {
+		k := dedupKey{fset.Position(d.Pos), d.Message}
+		if seen[k] || (keep != nil && !keep(fset, d)) {
+			return
+		}
+		seen[k] = true
+		out = append(out, sarifFinding{fset, d, sup})
+	}
+	for _, act := range graph.Roots {
+		for _, d := range act.Diagnostics {
+			add(act.Package.Fset, d, nil)
+		}
+		if res, ok := act.Result.(*zerologctx.Result); ok {
+			for _, sd := range res.Suppressed {
+				kind := "inSource"
+				if sd.Directive == zerologctx.AllowFuncsDirective {
+					kind = "external"
+				}
+				add(act.Package.Fset, sd.Diagnostic, &sarifSuppression{Kind: kind, Justification: sd.Directive})
+			}
+		}
+	}
+	for _, f := range baselined {
+		add(f.act.Package.Fset, f.diag, &sarifSuppression{Kind: "external", Justification: "recorded in baseline"})
+	}
+	return out
+}
+
+// sarifCategories describes the diagnostic categories the analyzer reports,
+// one SARIF rule each.
+var sarifCategories = []struct{ category, short string }{
+	{zerologctx.CategoryMissingCtx, "zerolog event missing .Ctx(ctx) while a context is available"},
+	{zerologctx.CategoryNilCtx, "zerolog event given a non-context argument to Ctx()"},
+}
+
+// sarifRuleID is the stable rule ID of a diagnostic category:
+// zerologctx/missing-ctx, the same form a //nolint directive accepts.
+func sarifRuleID(category string) string {
+	return zerologctx.Analyzer.Name + "/" + category
+}
+
+// writeSARIF emits the findings as a SARIF 2.1.0 log with one run and one
+// rule per diagnostic category, described by the analyzer's Doc. Locations
+// cover the whole reported chain; suggested fixes become SARIF fixes.
+func writeSARIF(w io.Writer, findings []sarifFinding) error {
+	doc := zerologctx.Analyzer.Doc
+	var rules []sarifRule
+	for _, c := range sarifCategories {
+		rules = append(rules, sarifRule{
+			ID:               sarifRuleID(c.category),
+			ShortDescription: sarifMessage{c.short},
+			FullDescription:  sarifMessage{doc},
+			Help:             sarifMessage{doc},
+		})
+	}
+	sc := newSourceCache()
+	results := []sarifResult{}
+	for _, f := range findings {
+		r := sarifResult{
+			RuleID:  sarifRuleID(f.diag.Category),
+			Level:   "warning",
+			Message: sarifMessage{f.diag.Message},
+			Locations: []sarifLocation{{sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact(f.fset.Position(f.diag.Pos).Filename),
+				Region:           sc.region(f.fset, f.diag.Pos, f.diag.End),
+			}}},
+		}
+		for _, fix := range f.diag.SuggestedFixes {
+			byFile := make(map[string]*sarifArtifactChange)
+			var order []string
+			for _, edit := range fix.TextEdits {
+				name := f.fset.Position(edit.Pos).Filename
+				change, ok := byFile[name]
+				if !ok {
+					change = &sarifArtifactChange{ArtifactLocation: sarifArtifact(name)}
+					byFile[name] = change
+					order = append(order, name)
+				}
+				change.Replacements = append(change.Replacements, sarifReplacement{
+					DeletedRegion:   sc.region(f.fset, edit.Pos, edit.End),
+					InsertedContent: sarifMessage{string(edit.NewText)},
+				})
+			}
+			sf := sarifFix{Description: sarifMessage{fix.Message}}
+			for _, name := range order {
+				sf.ArtifactChanges = append(sf.ArtifactChanges, *byFile[name])
+			}
+			r.Fixes = append(r.Fixes, sf)
+		}
+		if f.suppression != nil {
+			r.Suppressions = []sarifSuppression{*f.suppression}
+		}
+		results = append(results, r)
+	}
+
+	log := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           zerologctx.Analyzer.Name,
+				InformationURI: "https://github.com/tolmachov/zerologctx",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifArtifact locates a file relative to the working directory (the
+// %SRCROOT% code-scanning tools resolve against, normally the repository
+// root), or by absolute file URI when it lies outside it.
+func sarifArtifact(filename string) sarifArtifactLocation {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, filename); err == nil && !strings.HasPrefix(rel, "..") {
+			return sarifArtifactLocation{URI: filepath.ToSlash(rel), URIBaseID: "%SRCROOT%"}
+		}
+	}
+	return sarifArtifactLocation{URI: "file://" + filepath.ToSlash(filename)}
+}
+
+// sourceCache serves file contents for converting byte columns to UTF-16
+// columns.
+type sourceCache map[string][]byte
+
+func newSourceCache() sourceCache { return make(sourceCache) }
+
+// region converts the byte range [pos, end) to a SARIF region. A missing end
+// yields an empty region at pos.
+func (sc sourceCache) region(fset *token.FileSet, pos, end token.Pos) sarifRegion {
+	if !end.IsValid() {
+		end = pos
+	}
+	from, to := fset.Position(pos), fset.Position(end)
+	return sarifRegion{
+		StartLine:   from.Line,
+		StartColumn: sc.utf16Column(from),
+		EndLine:     to.Line,
+		EndColumn:   sc.utf16Column(to),
+	}
+}
+
+// utf16Column converts p's 1-based byte column to a 1-based UTF-16 column,
+// falling back to the byte column when the source cannot be read.
+func (sc sourceCache) utf16Column(p token.Position) int {
+	src, ok := sc[p.Filename]
+	if !ok {
+		src, _ = os.ReadFile(p.Filename)
+		sc[p.Filename] = src
+	}
+	lineStart := p.Offset - (p.Column - 1)
+	if src == nil || lineStart < 0 || p.Offset > len(src) {
+		return p.Column
+	}
+	col := 1
+	for b := src[lineStart:p.Offset]; len(b) > 0; {
+		r, size := utf8.DecodeRune(b)
+		col += utf16.RuneLen(r)
+		b = b[size:]
+	}
+	return col
+}
@@ -0,0 +1,336 @@
+package zerologctx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// ConfigFileNames are the policy file names looked up by FindConfig, in order
+// of preference.
+var ConfigFileNames = []string{".zerologctx.yml", ".zerologctx.yaml", ".zerologctx.json"}
+
+// LoadConfig reads a policy file and applies its settings to the flags of the
+// given analyzers, or of every analyzer of the family (Analyzers) when none is
+// given. Keys are analyzer flag names (allow-funcs, ...); values are scalars
+// or lists, lists being joined with commas as the corresponding flag expects.
+// A setting is applied to each given analyzer defining the flag, so one file
+// can hold settings only some analyzers have (ctx-loggers); keys no analyzer
+// of the family defines are an error naming the supported ones, so a typo
+// does not silently disable a setting.
+//
+// The file holds exactly the analyzers' flags. The libraries' import paths,
+// terminal methods and log levels, and the strictness of the checks, are
+// built into the analyzers and have no keys.
+//
+// Files ending in .json are parsed as JSON; anything else as YAML, limited to
+// what a flat settings file needs: a top-level mapping of scalars, flow
+// sequences ([a, b]) and block sequences ("- item" lines), with # comments
+// and single- or double-quoted strings. A /regexp/ item of a flow sequence is
+// read as one unit, commas included, as in the flag syntax.
+//
+// Settings are applied in the file's key order. Drivers apply the file before
+// parsing the command line, so an explicit flag overrides the file.
+func LoadConfig(path string, analyzers ...*analysis.Analyzer) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var settings []configSetting
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		settings, err = parseJSONConfig(data)
+	} else {
+		settings, err = parseYAMLConfig(data)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	family := Analyzers()
+	if len(analyzers) == 0 {
+		analyzers = family
+	}
+	for _, st := range settings {
+		if !definesFlag(family, st.key) && !definesFlag(analyzers, st.key) {
+			return fmt.Errorf("%s: unknown setting %q (supported: %s)", path, st.key,
+				strings.Join(flagNames(slices.Concat(family, analyzers)), ", "))
+		}
+		for _, a := range analyzers {
+			f := a.Flags.Lookup(st.key)
+			if f == nil {
+				continue
+			}
+			if err := f.Value.Set(st.value); err != nil {
+				return fmt.Errorf("%s: %s: %w", path, st.key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// definesFlag reports whether one of analyzers has the flag name.
+func definesFlag(analyzers []*analysis.Analyzer, name string) bool {
+	return slices.ContainsFunc(analyzers, func(a *analysis.Analyzer) bool {
+		return a.Flags.Lookup(name) != nil
+	})
+}
+
+// flagNames returns the sorted names of the flags of analyzers.
+func flagNames(analyzers []*analysis.Analyzer) []string {
+	var names []string
+	for _, a := range analyzers {
+		a.Flags.VisitAll(func(f *flag.Flag) { names = append(names, f.Name) })
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// FindConfig looks for one of ConfigFileNames in dir and its parents, stopping
+// at the module root (the first directory containing go.mod). It returns ""
+// with a nil error when there is no policy file.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		for _, name := range ConfigFileNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return "", err
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// configSetting is one key of a policy file with its value rendered in flag
+// syntax.
+type configSetting struct {
+	key, value string
+}
+
+// parseJSONConfig decodes a JSON object of scalars and arrays of scalars,
+// preserving key order.
+func parseJSONConfig(data []byte) ([]configSetting, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, errors.New("expected a JSON object")
+	}
+	var settings []configSetting
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+		var raw any
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		value, err := jsonSettingValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		settings = append(settings, configSetting{key, value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func jsonSettingValue(raw any) (string, error) {
+	switch v := raw.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, isList := item.([]any); isList {
+				return "", errors.New("nested lists are not supported")
+			}
+			s, err := jsonSettingValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", raw)
+}
+
+// parseYAMLConfig parses the flat YAML subset described on LoadConfig.
+func parseYAMLConfig(data []byte) ([]configSetting, error) {
+	var settings []configSetting
+	var list *configSetting // the key whose block sequence is being read
+	var items []string
+	flush := func() {
+		if list != nil {
+			list.value = strings.Join(items, ",")
+			settings = append(settings, *list)
+			list, items = nil, nil
+		}
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		lineNo := i + 1
+		text := strings.TrimRight(stripYAMLComment(line), " \t\r")
+		if strings.TrimSpace(text) == "" || text == "---" {
+			continue
+		}
+		trimmed := strings.TrimLeft(text, " \t")
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if list == nil || trimmed == text {
+				return nil, fmt.Errorf("line %d: unexpected list item", lineNo)
+			}
+			item, err := yamlScalar(strings.TrimSpace(strings.TrimPrefix(trimmed, "-")))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			items = append(items, item)
+			continue
+		}
+		flush()
+		if trimmed != text {
+			return nil, fmt.Errorf("line %d: nested mappings are not supported", lineNo)
+		}
+		key, rest, ok := strings.Cut(text, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		rest = strings.TrimSpace(rest)
+		switch {
+		case rest == "":
+			list = &configSetting{key: key}
+		case strings.HasPrefix(rest, "["):
+			if !strings.HasSuffix(rest, "]") {
+				return nil, fmt.Errorf("line %d: unterminated flow sequence", lineNo)
+			}
+			var elems []string
+			for _, elem := range splitFlowSequence(rest[1 : len(rest)-1]) {
+				v, err := yamlScalar(elem)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				elems = append(elems, v)
+			}
+			settings = append(settings, configSetting{key, strings.Join(elems, ",")})
+		default:
+			v, err := yamlScalar(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			settings = append(settings, configSetting{key, v})
+		}
+	}
+	flush()
+	return settings, nil
+}
+
+// splitFlowSequence splits the items of a flow sequence, without its
+// brackets, at the commas outside quoted strings and /regexp/ items. Items
+// are trimmed; empty ones are dropped.
+func splitFlowSequence(s string) []string {
+	var items []string
+	for s != "" {
+		s = strings.TrimLeft(s, " \t")
+		i := 0
+		switch {
+		case strings.HasPrefix(s, "/"):
+			i = max(regexpEnd(s), 0)
+		case strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`):
+			i = quotedEnd(s)
+		}
+		item := s
+		if j := strings.IndexByte(s[i:], ','); j >= 0 {
+			item, s = s[:i+j], s[i+j+1:]
+		} else {
+			s = ""
+		}
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// quotedEnd returns the index just past the quote closing the single- or
+// double-quoted scalar s starts with, or len(s) when it is unterminated.
+func quotedEnd(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++ // '' escapes a single quote
+		case s[i] == quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// stripYAMLComment removes a # comment that starts the line or follows
+// whitespace, leaving # inside quoted strings alone. A backslash escapes
+// the next character of a double-quoted string.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// yamlScalar unquotes a single- or double-quoted scalar; plain scalars are
+// returned as written.
+func yamlScalar(s string) (string, error) {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strconv.Unquote(s)
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	if s != "" && slices.Contains([]string{"{", "}", "&", "*", "|", ">"}, s[:1]) {
+		return "", fmt.Errorf("unsupported YAML value %q", s)
+	}
+	return s, nil
+}
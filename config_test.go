@@ -0,0 +1,149 @@
+package zerologctx
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// TestParseConfig pins the accepted YAML subset and the JSON form: both must
+// render the same settings in flag syntax, in file order.
+func TestParseConfig(t *testing.T) {
+	want := []configSetting{
+		{"allow-funcs", `example.com/cmd/app.run,/\.(main|init)$/`},
+		{"single", "a # not a comment"},
+		{"escaped", `a"b # c`},
+		{"flow", "x,y"},
+		{"regexps", `/\.(a|b){1,2}$/,/^x, y$/,it's`},
+	}
+
+	yaml := `# zerologctx policy
+---
+allow-funcs:
+  - example.com/cmd/app.run   # exact name
+  - '/\.(main|init)$/'
+single: "a # not a comment"
+escaped: "a\"b # c" # a comment
+flow: [x, 'y']
+regexps: [/\.(a|b){1,2}$/, "/^x, y$/", 'it''s']
+`
+	got, err := parseYAMLConfig([]byte(yaml))
+	if err != nil {
+		t.Fatalf("parseYAMLConfig: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAMLConfig = %q, want %q", got, want)
+	}
+
+	json := `{
+  "allow-funcs": ["example.com/cmd/app.run", "/\\.(main|init)$/"],
+  "single": "a # not a comment",
+  "escaped": "a\"b # c",
+  "flow": ["x", "y"],
+  "regexps": ["/\\.(a|b){1,2}$/", "/^x, y$/", "it's"]
+}`
+	got, err = parseJSONConfig([]byte(json))
+	if err != nil {
+		t.Fatalf("parseJSONConfig: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseJSONConfig = %q, want %q", got, want)
+	}
+
+	for _, bad := range []string{
+		"- orphan item",
+		"nested:\n  key: value",
+		"flow: [a, b",
+		"no colon here",
+		"anchor: &a x",
+	} {
+		if _, err := parseYAMLConfig([]byte(bad)); err == nil {
+			t.Errorf("parseYAMLConfig(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+// TestLoadConfig verifies that a policy file is applied to every analyzer of
+// the family defining the setting, or only to the given analyzers, and that
+// unknown keys are rejected.
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	var withAllowFuncs []*analysis.Analyzer
+	for _, a := range Analyzers() {
+		if a.Flags.Lookup("allow-funcs") != nil {
+			setAnalyzerFlag(t, a, "allow-funcs", "") // restore the default afterwards
+			withAllowFuncs = append(withAllowFuncs, a)
+		}
+	}
+	good := filepath.Join(dir, "good.yml")
+	writeFile(t, good, "allow-funcs: [example.com/cmd/app.run]\n")
+	if err := LoadConfig(good); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	for _, a := range withAllowFuncs {
+		if got := a.Flags.Lookup("allow-funcs").Value.String(); got != "example.com/cmd/app.run" {
+			t.Errorf("%s allow-funcs = %q after LoadConfig", a.Name, got)
+		}
+	}
+
+	setAnalyzerFlag(t, SlogAnalyzer, "allow-funcs", "")
+	zerologOnly := filepath.Join(dir, "zerolog-only.yml")
+	writeFile(t, zerologOnly, "ctx-loggers: true\nallow-funcs: example.com/cmd/app.main\n")
+	if err := LoadConfig(zerologOnly, SlogAnalyzer); err != nil {
+		t.Fatalf("LoadConfig(slogctx): %v", err)
+	}
+	if got := SlogAnalyzer.Flags.Lookup("allow-funcs").Value.String(); got != "example.com/cmd/app.main" {
+		t.Errorf("slogctx allow-funcs = %q after LoadConfig(slogctx)", got)
+	}
+	if got := Analyzer.Flags.Lookup("allow-funcs").Value.String(); got != "example.com/cmd/app.run" {
+		t.Errorf("zerologctx allow-funcs = %q after LoadConfig(slogctx), want it unchanged", got)
+	}
+
+	typo := filepath.Join(dir, "typo.json")
+	writeFile(t, typo, `{"allow-func": "x"}`)
+	err := LoadConfig(typo)
+	if err == nil {
+		t.Fatal("LoadConfig accepted an unknown setting")
+	}
+	for _, want := range []string{`unknown setting "allow-func"`, "allow-funcs", "require-ctx-decorators"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadConfig error %q does not contain %q", err, want)
+		}
+	}
+}
+
+// TestFindConfig verifies discovery from a nested directory up to, and not
+// beyond, the module root.
+func TestFindConfig(t *testing.T) {
+	root := t.TempDir()
+	module := filepath.Join(root, "module")
+	nested := filepath.Join(module, "internal", "pkg")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(module, "go.mod"), "module example.com/m\n")
+
+	// A policy file above the module root must not be picked up.
+	writeFile(t, filepath.Join(root, ".zerologctx.yml"), "")
+	if got, err := FindConfig(nested); err != nil || got != "" {
+		t.Errorf("FindConfig above module root = %q, %v; want none", got, err)
+	}
+
+	want := filepath.Join(module, ".zerologctx.yml")
+	writeFile(t, want, "")
+	if got, err := FindConfig(nested); err != nil || got != want {
+		t.Errorf("FindConfig = %q, %v; want %q", got, err, want)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,215 @@
+package zerologctx
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Container facts track maps, slices, arrays and channels of a tracked kind
+// (map[string]zerolog.Logger, []*zerolog.Logger, chan *zerolog.Event) held
+// in variables and fields. Element stores are not ordered: a container
+// yields values with context, on index, receive and range, when it has at
+// least one element store and every one of them stores a value with
+// context. A single store without context — or one the analyzer cannot
+// classify, such as a call result — clears it for the whole package.
+//
+// Element stores are m[k] = v, ch <- v, the elements of a composite literal
+// assigned to the container, the values appended to it, and the elements of
+// another container assigned or appended to it. make() and nil store
+// nothing. Function-typed variables are tracked the same way (see
+// funcvars.go).
+
+// elemStores summarises the element stores of one container seen during a
+// fact-collection pass.
+type elemStores struct {
+	withCtx, withoutCtx bool
+}
+
+// elemKind returns the track kind of the elements of a map, slice, array or
+// channel type (or a pointer to an array), or trackNone.
+func (s *state) elemKind(t types.Type) trackKind {
+	if t == nil {
+		return trackNone
+	}
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		if arr, ok := ptr.Elem().Underlying().(*types.Array); ok {
+			return s.prof.kindOf(arr.Elem())
+		}
+		return trackNone
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Map:
+		return s.prof.kindOf(u.Elem())
+	case *types.Slice:
+		return s.prof.kindOf(u.Elem())
+	case *types.Array:
+		return s.prof.kindOf(u.Elem())
+	case *types.Chan:
+		return s.prof.kindOf(u.Elem())
+	}
+	return trackNone
+}
+
+// storeElem records an element store into the container obj.
+func (s *state) storeElem(obj types.Object, withCtx bool) {
+	st := s.elemStores[obj]
+	if st == nil {
+		st = &elemStores{}
+		s.elemStores[obj] = st
+	}
+	if withCtx {
+		st.withCtx = true
+	} else {
+		st.withoutCtx = true
+	}
+}
+
+// recordIndexStore records the element store m[k] = rhs.
+func (s *state) recordIndexStore(idx *ast.IndexExpr, pos token.Pos, rhs ast.Expr) {
+	obj := s.objectFromExpr(idx.X)
+	if obj == nil {
+		return
+	}
+	ek := s.elemKind(obj.Type())
+	if ek == trackNone {
+		return
+	}
+	s.storeElem(obj, rhs != nil && s.exprHasCtx(ek, rhs, pos))
+}
+
+// recordContainer records the element stores made by assigning rhs to the
+// container obj, whose elements are of kind ek.
+func (s *state) recordContainer(obj types.Object, ek trackKind, pos token.Pos, rhs ast.Expr) {
+	switch x := ast.Unparen(rhs).(type) {
+	case *ast.CompositeLit:
+		for _, elt := range x.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			s.storeElem(obj, s.exprHasCtx(ek, elt, pos))
+		}
+		return
+	case *ast.CallExpr:
+		switch s.builtinName(x.Fun) {
+		case "make":
+			return
+		case "append":
+			for i, arg := range x.Args {
+				switch {
+				case i == 0 || x.Ellipsis.IsValid() && i == len(x.Args)-1:
+					// The slice appended to, or spread into the call.
+					if s.objectFromExpr(arg) != obj && !s.isNil(arg) {
+						s.storeElem(obj, s.containerHasCtx(arg))
+					}
+				default:
+					s.storeElem(obj, s.exprHasCtx(ek, arg, pos))
+				}
+			}
+			return
+		}
+	}
+	if s.isNil(rhs) || s.objectFromExpr(rhs) == obj {
+		return
+	}
+	s.storeElem(obj, s.containerHasCtx(rhs))
+}
+
+// containerHasCtx reports whether expr resolves to a container whose
+// elements have context, as of the previous fact-collection pass.
+func (s *state) containerHasCtx(expr ast.Expr) bool {
+	obj := s.objectFromExpr(expr)
+	return obj != nil && s.containers[obj]
+}
+
+// elemFactIs is factIs for an index expression or a receive (<-ch): whether
+// the container x yields values whose fact is kind.
+func (s *state) elemFactIs(x ast.Expr, pos token.Pos, kind factKind) bool {
+	obj := s.objectFromExpr(x)
+	if obj == nil || positiveFactFor(s.elemKind(obj.Type())) != kind {
+		s.note(pos, "%s is not a tracked container", types.ExprString(x))
+		return false
+	}
+	if s.containers[obj] {
+		s.note(pos, "every element stored in %s is %s", obj.Name(), kind)
+		return true
+	}
+	s.note(pos, "not every element stored in %s is %s", obj.Name(), kind)
+	return false
+}
+
+// handleSend records the element store ch <- v.
+func (s *state) handleSend(node *ast.SendStmt) {
+	obj := s.objectFromExpr(node.Chan)
+	if obj == nil {
+		return
+	}
+	if ek := s.elemKind(obj.Type()); ek != trackNone {
+		s.storeElem(obj, s.exprHasCtx(ek, node.Value, node.Pos()))
+	}
+}
+
+// handleRange records the fact of a range statement's value variable (the
+// only one, over a channel), an element of a tracked container.
+func (s *state) handleRange(node *ast.RangeStmt) {
+	elem := node.Value
+	if _, ok := s.pass.TypesInfo.TypeOf(node.X).Underlying().(*types.Chan); ok {
+		elem = node.Key
+	}
+	if elem == nil {
+		return
+	}
+	obj := s.objectFromExpr(elem)
+	tk := s.trackKindOfObj(obj)
+	if tk == trackNone || s.elemKind(s.pass.TypesInfo.TypeOf(node.X)) != tk {
+		return
+	}
+	kind := factNone
+	if s.containerHasCtx(node.X) {
+		kind = positiveFactFor(tk)
+	}
+	s.facts.set(obj, node.Pos(), kind)
+}
+
+// endContainerPass derives the container facts from the element stores of
+// the pass just completed, resets the stores for the next pass, and reports
+// whether any container fact changed.
+func (s *state) endContainerPass() bool {
+	next := make(map[types.Object]bool, len(s.elemStores))
+	changed := false
+	for obj, st := range s.elemStores {
+		if st.withCtx && !st.withoutCtx {
+			next[obj] = true
+			changed = changed || !s.containers[obj]
+		}
+	}
+	for obj := range s.containers {
+		changed = changed || !next[obj]
+	}
+	s.containers = next
+	s.elemStores = make(map[types.Object]*elemStores)
+	return changed
+}
+
+// builtinName returns the name of the builtin fun denotes, or "".
+func (s *state) builtinName(fun ast.Expr) string {
+	id, ok := ast.Unparen(fun).(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if b, ok := s.pass.TypesInfo.Uses[id].(*types.Builtin); ok {
+		return b.Name()
+	}
+	return ""
+}
+
+// isNil reports whether e is the predeclared nil or a conversion of it
+// ([]*zerolog.Logger(nil)).
+func (s *state) isNil(e ast.Expr) bool {
+	e = ast.Unparen(e)
+	if call, ok := e.(*ast.CallExpr); ok && len(call.Args) == 1 && s.pass.TypesInfo.Types[call.Fun].IsType() {
+		return s.isNil(call.Args[0])
+	}
+	tv, ok := s.pass.TypesInfo.Types[e]
+	return ok && tv.IsNil()
+}
@@ -0,0 +1,275 @@
+package zerologctx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ctxLoggerFact is exported for a function that hands out contexts carrying
+// a logger with context, so that callers in other packages can rely on them
+// under -ctx-loggers.
+type ctxLoggerFact struct {
+	// Result is set when every context the function returns carries one.
+	Result bool
+	// Callbacks are the indices of the function-typed parameters the
+	// function calls, and only ever calls with contexts carrying one.
+	Callbacks []int
+}
+
+func (*ctxLoggerFact) AFact() {}
+
+func (f *ctxLoggerFact) String() string {
+	var parts []string
+	if f.Result {
+		parts = append(parts, "result")
+	}
+	for _, i := range f.Callbacks {
+		parts = append(parts, fmt.Sprintf("callback %d", i))
+	}
+	return "ctxLogger(" + strings.Join(parts, ", ") + ")"
+}
+
+// ctxLoggerMode reports whether the -ctx-loggers analysis runs: it must be
+// enabled, and the running analyzer must declare ctxLoggerFact (only
+// Analyzer does; the other profiles have no context-stored loggers).
+func (s *state) ctxLoggerMode() bool {
+	return (s.cfg.ctxLoggers || len(s.cfg.trustedMiddleware.raw) > 0) && len(s.pass.Analyzer.FactTypes) > 0
+}
+
+// carriesLogger reports whether the context expression e, evaluated at at,
+// carries a logger with context: one stored by a Logger.WithContext call on
+// a logger with context, returned by -trusted-middleware or by a function
+// with a ctxLoggerFact, derived from such a context by the context package's
+// With* functions, or held by a variable assigned one of these (or a
+// parameter the context is passed to, see updateCtxLoggers). Like
+// ctxDecorated it is flow-insensitive.
+func (s *state) carriesLogger(e ast.Expr, at token.Pos) bool {
+	switch x := ast.Unparen(e).(type) {
+	case *ast.CallExpr:
+		fn, ok := typeutil.Callee(s.pass.TypesInfo, x).(*types.Func)
+		if !ok {
+			return false
+		}
+		if logger := s.prof.storesLogger(x, fn, s.calleeRecvKind(x)); logger != nil {
+			if s.loggerHasCtx(logger, at) {
+				s.note(x.Pos(), "%s stores a logger with context in the context", types.ExprString(x.Fun))
+				return true
+			}
+			s.note(x.Pos(), "%s stores a logger without context in the context", types.ExprString(x.Fun))
+			return false
+		}
+		if s.cfg.trustedMiddleware.matches(fn) {
+			s.note(x.Pos(), "%s is listed in -trusted-middleware", fn.FullName())
+			return true
+		}
+		if f := s.ctxLoggerFactOf(fn); f != nil && f.Result {
+			s.note(x.Pos(), "%s returns contexts carrying a logger with context", fn.FullName())
+			return true
+		}
+		if fn.Pkg() != nil && fn.Pkg().Path() == "context" && strings.HasPrefix(fn.Name(), "With") && len(x.Args) > 0 {
+			return s.carriesLogger(x.Args[0], x.Args[0].Pos())
+		}
+	case *ast.Ident:
+		obj, ok := s.pass.TypesInfo.Uses[x].(*types.Var)
+		if !ok {
+			return false
+		}
+		assigns := s.ctxAssignmentsOf(obj)
+		for i := len(assigns) - 1; i >= 0; i-- {
+			if assigns[i].to <= at {
+				return s.carriesLogger(assigns[i].rhs, assigns[i].from)
+			}
+		}
+		if s.trustedParams[obj] {
+			s.note(x.Pos(), "%s is passed a context carrying a logger with context", x.Name)
+			return true
+		}
+	}
+	return false
+}
+
+// loadedLoggerCtx returns the context argument of call when it returns the
+// logger stored in a context (zerolog.Ctx(ctx)), or nil.
+func (s *state) loadedLoggerCtx(call *ast.CallExpr, recv trackKind) ast.Expr {
+	fn, ok := typeutil.Callee(s.pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return nil
+	}
+	return s.prof.loadsLogger(call, fn, recv)
+}
+
+// ctxLoggerFactOf returns what is known about fn's contexts: the summary
+// computed for a function of the package, or the fact exported by its own.
+func (s *state) ctxLoggerFactOf(fn *types.Func) *ctxLoggerFact {
+	fn = fn.Origin()
+	if fn.Pkg() == s.pass.Pkg {
+		return s.ctxLoggerFuncs[fn]
+	}
+	if len(s.pass.Analyzer.FactTypes) == 0 {
+		return nil
+	}
+	var f ctxLoggerFact
+	if !s.pass.ImportObjectFact(fn, &f) {
+		return nil
+	}
+	return &f
+}
+
+// updateCtxLoggers recomputes, from the facts collected so far, the summary
+// of every function of the package and the set of parameters receiving a
+// context that carries a logger with context: the context parameters of the
+// functions passed to -trusted-middleware, or passed as a callback a
+// ctxLoggerFact lists. Both only grow as facts are learnt; it reports
+// whether either changed, for the collectFacts fixpoint.
+func (s *state) updateCtxLoggers() bool {
+	if s.ctxLoggerFuncs == nil {
+		s.ctxLoggerFuncs = make(map[*types.Func]*ctxLoggerFact)
+		s.trustedParams = make(map[types.Object]bool)
+	}
+	changed := false
+	for fn, fd := range s.funcDeclMap() {
+		f := s.summarize(fn, fd)
+		if old := s.ctxLoggerFuncs[fn]; old == nil && f != nil ||
+			old != nil && f != nil && (old.Result != f.Result || !slices.Equal(old.Callbacks, f.Callbacks)) {
+			s.ctxLoggerFuncs[fn] = f
+			changed = true
+		}
+	}
+	for _, file := range s.pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, ok := typeutil.Callee(s.pass.TypesInfo, call).(*types.Func)
+			if !ok {
+				return true
+			}
+			trusted := s.cfg.trustedMiddleware.matches(fn)
+			f := s.ctxLoggerFactOf(fn)
+			for i, arg := range call.Args {
+				if !trusted && (f == nil || !slices.Contains(f.Callbacks, i)) {
+					continue
+				}
+				for _, p := range s.funcArgParams(arg) {
+					if s.isContextType(p.Type()) && !s.trustedParams[p] {
+						s.trustedParams[p] = true
+						changed = true
+					}
+				}
+			}
+			return true
+		})
+	}
+	return changed
+}
+
+// funcArgParams returns the parameters of the function a call argument
+// denotes: a function literal, or a function or method value of the package.
+func (s *state) funcArgParams(arg ast.Expr) []types.Object {
+	var ft *ast.FuncType
+	switch x := ast.Unparen(arg).(type) {
+	case *ast.FuncLit:
+		ft = x.Type
+	default:
+		fn, ok := s.objectFromExpr(x).(*types.Func)
+		if !ok {
+			return nil
+		}
+		fd := s.funcDeclMap()[fn.Origin()]
+		if fd == nil {
+			return nil
+		}
+		ft = fd.Type
+	}
+	var params []types.Object
+	for _, field := range ft.Params.List {
+		for _, name := range field.Names {
+			if obj := s.pass.TypesInfo.Defs[name]; obj != nil {
+				params = append(params, obj)
+			}
+		}
+	}
+	return params
+}
+
+// summarize computes fn's ctxLoggerFact, or nil when it hands out no context
+// carrying a logger with context. Returns inside function literals belong to
+// the literal and are skipped; calls of a callback parameter are found
+// anywhere in the body.
+func (s *state) summarize(fn *types.Func, fd *ast.FuncDecl) *ctxLoggerFact {
+	sig := fn.Signature()
+	var f ctxLoggerFact
+
+	returns := returnStmts(fd.Body)
+	results := sig.Results()
+	hasCtxResult := false
+	for v := range results.Variables() {
+		hasCtxResult = hasCtxResult || s.isContextType(v.Type())
+	}
+	if hasCtxResult && len(returns) > 0 {
+		f.Result = true
+		for _, ret := range returns {
+			switch {
+			case len(ret.Results) == 1 && results.Len() > 1: // return g()
+				f.Result = f.Result && s.carriesLogger(ret.Results[0], ret.Pos())
+			case len(ret.Results) == results.Len():
+				for i, r := range ret.Results {
+					if s.isContextType(results.At(i).Type()) {
+						f.Result = f.Result && s.carriesLogger(r, ret.Pos())
+					}
+				}
+			default: // bare return of named results
+				f.Result = false
+			}
+		}
+	}
+
+	for i := range sig.Params().Len() {
+		param := sig.Params().At(i)
+		if _, ok := param.Type().Underlying().(*types.Signature); !ok {
+			continue
+		}
+		calls, ok := 0, true
+		ast.Inspect(fd.Body, func(n ast.Node) bool {
+			call, isCall := n.(*ast.CallExpr)
+			if !isCall || !ok {
+				return ok
+			}
+			if id, isIdent := ast.Unparen(call.Fun).(*ast.Ident); !isIdent || s.pass.TypesInfo.Uses[id] != param {
+				return true
+			}
+			calls++
+			passed := false
+			for _, arg := range call.Args {
+				if s.isContextExpr(arg) {
+					passed = true
+					ok = ok && s.carriesLogger(arg, call.Pos())
+				}
+			}
+			ok = ok && passed
+			return ok
+		})
+		if calls > 0 && ok {
+			f.Callbacks = append(f.Callbacks, i)
+		}
+	}
+
+	if !f.Result && len(f.Callbacks) == 0 {
+		return nil
+	}
+	return &f
+}
+
+// exportCtxLoggerFacts exports the package's function summaries.
+func (s *state) exportCtxLoggerFacts() {
+	for fn, f := range s.ctxLoggerFuncs {
+		s.pass.ExportObjectFact(fn, f)
+	}
+}
@@ -0,0 +1,285 @@
+package zerologctx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// UndecoratedCtxAnalyzer is the undecoratedctx analyzer: with
+// -require-ctx-decorators, it reports the contexts attached to log entries
+// that do not come from a required decorator, for every library of the
+// family.
+var UndecoratedCtxAnalyzer = &analysis.Analyzer{
+	Name: "undecoratedctx",
+	Doc: `Ensures contexts given to loggers come from a required context decorator.
+This opt-in analyzer checks the contexts attached to log entries — passed
+to zerolog's Ctx(), to logr.FromContext, in a zap field or to a log/slog
+*Context call — when -require-ctx-decorators lists functions that decorate
+a context with the values log correlation depends on (a request ID). Each
+must be traceable, within its function, to a call of one of them or to a
+parameter. Calls inside the functions listed by -allow-funcs are not
+reported.`,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	Run:        runUndecorated,
+	ResultType: reflect.TypeFor[*Result](),
+}
+
+// runUndecorated is the undecoratedctx entry point. It checks the calls
+// attaching a context of every library the package imports, each judged by
+// its profile (log/slog by its *Context calls), and reports into one Result.
+func runUndecorated(pass *analysis.Pass) (any, error) {
+	insp, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok {
+		return nil, fmt.Errorf("undecoratedctx: inspect.Analyzer result missing or wrong type")
+	}
+	res := &Result{}
+	cfg := &undecoratedSettings
+	if len(cfg.requireDecorators.raw) == 0 {
+		return res, nil
+	}
+	var states []*state
+	profiles := []libraryProfile{zerologProfile{}, logrProfile{}, zapProfile{ctxFields: &cfg.ctxFields}, nil}
+	for _, prof := range profiles {
+		pkgPath := slogPkgPath
+		if prof != nil {
+			pkgPath = prof.pkgPath()
+		}
+		// Without a context in the import graph there is no context to
+		// check.
+		hasLib, contextIface := scanImports(pass.Pkg, pkgPath)
+		if !hasLib || contextIface == nil || inPackage(pass.Pkg.Path(), pkgPath) {
+			continue
+		}
+		s, err := newState(pass, prof, cfg, contextIface)
+		if err != nil {
+			return nil, err
+		}
+		s.result = res
+		states = append(states, s)
+	}
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		for _, s := range states {
+			if s.prof == nil {
+				if fn := s.slogCallee(call); fn != nil && slogTakesContext(fn) && s.callArgIsContext(call) {
+					s.checkDecorated(call, fn.Name())
+					return
+				}
+				continue
+			}
+			if s.attaches(call, s.calleeRecvKind(call)) == attachCtx {
+				if id := calleeIdent(call.Fun); id != nil {
+					s.checkDecorated(call, id.Name)
+				}
+				return
+			}
+		}
+	})
+
+	for _, s := range states {
+		if s.readErr != nil {
+			return nil, fmt.Errorf("undecoratedctx: reading source for nolint processing: %w", s.readErr)
+		}
+	}
+	return res, nil
+}
+
+// ctxAssignment is one assignment of a context variable: rhs is the assigned
+// expression, spanning the statement [from, to).
+type ctxAssignment struct {
+	from, to token.Pos
+	rhs      ast.Expr
+}
+
+// fieldProfile is implemented by the profiles of libraries attaching a
+// context through a field constructor among the arguments (zap.Any("ctx",
+// ctx)).
+type fieldProfile interface {
+	// isCtxField reports whether fn builds a field holding the value it is
+	// given.
+	isCtxField(fn *types.Func) bool
+}
+
+// checkDecorated reports the contexts call — a call attaching a context to
+// a log entry — attaches that cannot be traced to a required decorator:
+// for log/slog the context parameter, otherwise the context arguments and
+// the contexts given to a field constructor holding them (zap.Any("ctx",
+// ctx)). Contexts passed to other calls among the arguments only compute a
+// value and are not checked.
+func (s *state) checkDecorated(call *ast.CallExpr, callee string) {
+	if len(s.cfg.requireDecorators.raw) == 0 {
+		return
+	}
+	if s.prof == nil {
+		s.reportUndecorated(call, call.Args[0], callee)
+		return
+	}
+	fields, _ := s.prof.(fieldProfile)
+	for _, arg := range call.Args {
+		if s.isContextExpr(arg) {
+			s.reportUndecorated(call, arg, callee)
+			continue
+		}
+		c, ok := ast.Unparen(arg).(*ast.CallExpr)
+		if !ok || fields == nil {
+			continue
+		}
+		if fn, _ := typeutil.Callee(s.pass.TypesInfo, c).(*types.Func); !fields.isCtxField(fn) {
+			continue
+		}
+		for _, a := range c.Args {
+			if s.isContextExpr(a) {
+				s.reportUndecorated(call, a, callee)
+			}
+		}
+	}
+}
+
+// reportUndecorated reports arg unless it is decorated, subject to
+// -allow-funcs and //nolint like the other diagnostics.
+func (s *state) reportUndecorated(call *ast.CallExpr, arg ast.Expr, callee string) {
+	if s.ctxDecorated(arg, arg.Pos()) {
+		return
+	}
+	diag := analysis.Diagnostic{
+		Pos:      arg.Pos(),
+		End:      arg.End(),
+		Category: CategoryUndecoratedCtx,
+		Message: fmt.Sprintf(
+			"context %s passed to %s() is not derived from a required context decorator (%s) or a parameter - it may lack the values log correlation depends on",
+			types.ExprString(arg), callee, s.cfg.requireDecorators.String(),
+		),
+	}
+	if s.inAllowedFunc(call.Pos()) {
+		s.result.Suppressed = append(s.result.Suppressed, SuppressedDiagnostic{Diagnostic: diag, Directive: AllowFuncsDirective})
+		return
+	}
+	if c := s.noLintDirective(call, call.Rparen, diag.Category); c != nil {
+		s.result.Suppressed = append(s.result.Suppressed, SuppressedDiagnostic{Diagnostic: diag, Directive: c.Text})
+		return
+	}
+	s.pass.Report(diag)
+}
+
+// ctxDecorated reports whether the context expression e, evaluated at at, is
+// the result of a required decorator, a function parameter, or derived from
+// either by the context package's With* functions or by assignment within
+// the function. Like fact collection it is flow-insensitive: the assignment
+// preceding at in source order decides.
+func (s *state) ctxDecorated(e ast.Expr, at token.Pos) bool {
+	switch x := ast.Unparen(e).(type) {
+	case *ast.CallExpr:
+		fn := typeutil.StaticCallee(s.pass.TypesInfo, x)
+		if fn == nil {
+			return false
+		}
+		if s.cfg.requireDecorators.matches(fn) {
+			return true
+		}
+		// context.WithValue(parent, ...), WithTimeout(parent, ...) and the
+		// like keep the parent's values.
+		if fn.Pkg() != nil && fn.Pkg().Path() == "context" && strings.HasPrefix(fn.Name(), "With") && len(x.Args) > 0 {
+			return s.ctxDecorated(x.Args[0], x.Args[0].Pos())
+		}
+	case *ast.Ident:
+		obj, ok := s.pass.TypesInfo.Uses[x].(*types.Var)
+		if !ok {
+			return false
+		}
+		assigns := s.ctxAssignmentsOf(obj)
+		for i := len(assigns) - 1; i >= 0; i-- {
+			if assigns[i].to <= at {
+				return s.ctxDecorated(assigns[i].rhs, assigns[i].from)
+			}
+		}
+		return s.paramSet()[obj]
+	}
+	return false
+}
+
+// ctxAssignmentsOf returns the assignments to obj in source order, indexing
+// every context-typed variable of the package on first use. A tuple
+// assignment from a single call (ctx, cancel := context.WithCancel(parent))
+// records the call for its first target.
+func (s *state) ctxAssignmentsOf(obj types.Object) []ctxAssignment {
+	if s.ctxAssigns == nil {
+		s.ctxAssigns = make(map[types.Object][]ctxAssignment)
+		record := func(lhs ast.Expr, from, to token.Pos, rhs ast.Expr) {
+			id, ok := ast.Unparen(lhs).(*ast.Ident)
+			if !ok {
+				return
+			}
+			if o := s.pass.TypesInfo.ObjectOf(id); o != nil && s.isContextType(o.Type()) {
+				s.ctxAssigns[o] = append(s.ctxAssigns[o], ctxAssignment{from, to, rhs})
+			}
+		}
+		for _, f := range s.pass.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				var lhs, rhs []ast.Expr
+				switch n := n.(type) {
+				case *ast.AssignStmt:
+					lhs, rhs = n.Lhs, n.Rhs
+				case *ast.ValueSpec:
+					for _, name := range n.Names {
+						lhs = append(lhs, name)
+					}
+					rhs = n.Values
+				default:
+					return true
+				}
+				switch {
+				case len(lhs) == len(rhs):
+					for i := range lhs {
+						record(lhs[i], n.Pos(), n.End(), rhs[i])
+					}
+				case len(rhs) == 1 && len(lhs) > 0:
+					record(lhs[0], n.Pos(), n.End(), rhs[0])
+				}
+				return true
+			})
+		}
+	}
+	return s.ctxAssigns[obj]
+}
+
+// paramSet returns (building lazily) the set of function and function
+// literal parameters in the package.
+func (s *state) paramSet() map[types.Object]bool {
+	if s.params != nil {
+		return s.params
+	}
+	s.params = make(map[types.Object]bool)
+	for _, f := range s.pass.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			ft, ok := n.(*ast.FuncType)
+			if !ok || ft.Params == nil {
+				return true
+			}
+			for _, field := range ft.Params.List {
+				for _, name := range field.Names {
+					if obj := s.pass.TypesInfo.Defs[name]; obj != nil {
+						s.params[obj] = true
+					}
+				}
+			}
+			return true
+		})
+	}
+	return s.params
+}
+
+// isContextExpr reports whether e's type satisfies context.Context.
+func (s *state) isContextExpr(e ast.Expr) bool {
+	t := s.pass.TypesInfo.TypeOf(e)
+	return t != nil && s.isContextType(t)
+}
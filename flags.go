@@ -0,0 +1,189 @@
+package zerologctx
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"regexp"
+	"strings"
+)
+
+// settings holds the flag values of one analyzer. Every analyzer of the
+// family has its own, bound to its own flags, so that under logctx
+// -zerologctx.allow-funcs leaves slogctx's list alone.
+type settings struct {
+	// allowFuncs lists the functions inside which findings are not
+	// reported. Bound to -allow-funcs.
+	allowFuncs funcPatterns
+	// explain makes the analyzer record the reasoning behind each decision.
+	// Bound to -explain.
+	explain bool
+	// ctxLoggers makes a logger loaded from a context (zerolog.Ctx(ctx),
+	// log.Ctx(ctx)) count as having context when ctx provably carries a
+	// logger with context; trustedMiddleware lists the functions storing one
+	// in the contexts they produce, and enables ctxLoggers. Bound to
+	// -ctx-loggers and -trusted-middleware.
+	ctxLoggers        bool
+	trustedMiddleware funcPatterns
+	// ctxFields lists the project's helpers building a zap field from the
+	// context they are given. Bound to -ctx-fields.
+	ctxFields funcPatterns
+	// requireDecorators lists the functions that put the values log
+	// correlation depends on (a request ID, a trace span) into a context: a
+	// context attached to a log entry must be traceable, within its
+	// function, to a call of one of them or to a parameter. Bound to
+	// -require-ctx-decorators; empty disables the check.
+	requireDecorators funcPatterns
+}
+
+// The settings of the analyzers with flags.
+var (
+	zerologSettings     settings
+	slogSettings        settings
+	logrSettings        settings
+	zapSettings         settings
+	undecoratedSettings settings
+)
+
+func init() {
+	zerologSettings.registerCommon(&Analyzer.Flags)
+	zerologSettings.registerCtxLoggers(&Analyzer.Flags)
+	slogSettings.registerCommon(&SlogAnalyzer.Flags)
+	logrSettings.registerCommon(&LogrAnalyzer.Flags)
+	zapSettings.registerCommon(&ZapAnalyzer.Flags)
+	zapSettings.registerCtxFields(&ZapAnalyzer.Flags)
+
+	undecoratedSettings.registerAllowFuncs(&UndecoratedCtxAnalyzer.Flags)
+	UndecoratedCtxAnalyzer.Flags.Var(&undecoratedSettings.requireDecorators, "require-ctx-decorators",
+		"comma-separated fully-qualified functions or /regexp/ patterns returning a derived context (e.g. example.com/requestid.With); "+
+			"contexts passed to the logger must come from one of them or from a parameter")
+	undecoratedSettings.registerCtxFields(&UndecoratedCtxAnalyzer.Flags)
+}
+
+// registerCommon binds the settings of the analyzers reporting calls without
+// context to fs.
+func (c *settings) registerCommon(fs *flag.FlagSet) {
+	c.registerAllowFuncs(fs)
+	fs.BoolVar(&c.explain, "explain", false,
+		"attach the reasoning behind each finding (facts, assignments, context candidate) as related information")
+}
+
+func (c *settings) registerAllowFuncs(fs *flag.FlagSet) {
+	fs.Var(&c.allowFuncs, "allow-funcs",
+		"comma-separated fully-qualified functions (e.g. example.com/cmd/app.run, (*example.com/pkg.Server).Start) "+
+			"or /regexp/ patterns matched against them, inside which calls are not reported")
+}
+
+// registerCtxLoggers binds zerolog's context-stored logger settings to fs.
+func (c *settings) registerCtxLoggers(fs *flag.FlagSet) {
+	fs.BoolVar(&c.ctxLoggers, "ctx-loggers", false,
+		"treat loggers loaded with zerolog.Ctx(ctx) or log.Ctx(ctx) as having context when ctx provably carries a logger with context "+
+			"(stored by Logger.WithContext, or produced by a function known to do so)")
+	fs.Var(&c.trustedMiddleware, "trusted-middleware",
+		"comma-separated fully-qualified functions or /regexp/ patterns storing a logger with context in the contexts they return "+
+			"and pass to the functions given to them; implies -ctx-loggers")
+}
+
+// registerCtxFields binds zap's context field helpers to fs.
+func (c *settings) registerCtxFields(fs *flag.FlagSet) {
+	fs.Var(&c.ctxFields, "ctx-fields",
+		"comma-separated fully-qualified functions or /regexp/ patterns building a zap field from the context they are given "+
+			"(e.g. example.com/logging.CtxField); zap.Any and zap.Reflect given the context always count")
+}
+
+// funcPatterns is a flag.Value holding a list of fully-qualified function
+// names and regular expressions, matched against types.Func.FullName. A
+// /-delimited entry is a regular expression (unanchored, as with go test
+// -run) and may itself contain commas; anything else must match exactly.
+// Each Set replaces the list, so a later flag or config value overrides an
+// earlier one, and String returns a value Set parses back to the same list.
+type funcPatterns struct {
+	names   map[string]bool
+	regexps []*regexp.Regexp
+	raw     []string
+}
+
+func (p *funcPatterns) String() string {
+	if p == nil {
+		return ""
+	}
+	return strings.Join(p.raw, ",")
+}
+
+func (p *funcPatterns) Set(value string) error {
+	next := funcPatterns{names: make(map[string]bool)}
+	for _, entry := range splitPatterns(value) {
+		if len(entry) >= 2 && strings.HasPrefix(entry, "/") && strings.HasSuffix(entry, "/") {
+			re, err := regexp.Compile(entry[1 : len(entry)-1])
+			if err != nil {
+				return fmt.Errorf("invalid function pattern %s: %w", entry, err)
+			}
+			next.regexps = append(next.regexps, re)
+		} else {
+			next.names[entry] = true
+		}
+		next.raw = append(next.raw, entry)
+	}
+	*p = next
+	return nil
+}
+
+// splitPatterns splits a comma-separated pattern list into its trimmed,
+// non-empty entries. A /regexp/ entry is read as one unit: it ends at the
+// first unescaped slash followed by a comma or the end of the list, so
+// commas inside it (`/\.(a|b){1,2}$/`) do not split it.
+func splitPatterns(value string) []string {
+	var entries []string
+	for value != "" {
+		value = strings.TrimLeft(value, " \t")
+		from := 0
+		if strings.HasPrefix(value, "/") {
+			from = max(regexpEnd(value), 0)
+		}
+		entry := value
+		if i := strings.IndexByte(value[from:], ','); i >= 0 {
+			entry, value = value[:from+i], value[from+i+1:]
+		} else {
+			value = ""
+		}
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// regexpEnd returns the index just past the slash closing the /regexp/ entry
+// value starts with — the first unescaped one followed, after blanks, by a
+// comma or the end of value — or -1 when there is none.
+func regexpEnd(value string) int {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '/':
+			rest := strings.TrimLeft(value[i+1:], " \t")
+			if rest == "" || rest[0] == ',' {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// matches reports whether fn is listed by name or matched by a pattern.
+func (p *funcPatterns) matches(fn *types.Func) bool {
+	if fn == nil || len(p.raw) == 0 {
+		return false
+	}
+	name := fn.FullName()
+	if p.names[name] {
+		return true
+	}
+	for _, re := range p.regexps {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package zerologctx
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// Function-typed variables and fields returning a tracked value
+// (`newEvent func() *zerolog.Event`) are tracked like containers (see
+// containers.go): every function assigned to one is a store, and calling the
+// variable yields a value with context when every store does. A function
+// literal stores one with context when all of its returns have context, a
+// function or method value when its result summary says it always does;
+// another function variable copies its stores, and nil stores nothing.
+// Anything else, a parameter or a call result, clears the variable.
+
+// funcResultKind returns the track kind of the single result of a function
+// type, or trackNone.
+func (s *state) funcResultKind(t types.Type) trackKind {
+	if t == nil {
+		return trackNone
+	}
+	sig, ok := t.Underlying().(*types.Signature)
+	if !ok || sig.Results().Len() != 1 {
+		return trackNone
+	}
+	return s.prof.kindOf(sig.Results().At(0).Type())
+}
+
+// recordFuncVar records the store made by assigning rhs to the function
+// variable obj.
+func (s *state) recordFuncVar(obj types.Object, pos token.Pos, rhs ast.Expr) {
+	switch x := ast.Unparen(rhs).(type) {
+	case *ast.FuncLit:
+		sig, _ := s.pass.TypesInfo.TypeOf(x).(*types.Signature)
+		returns := returnStmts(x.Body)
+		s.storeElem(obj, sig != nil && len(returns) > 0 && s.returnsHaveCtx(sig, returns))
+		return
+	}
+	if s.isNil(rhs) || s.objectFromExpr(rhs) == obj {
+		return
+	}
+	switch src := s.objectFromExpr(rhs).(type) {
+	case *types.Func:
+		f := s.ctxResultOf(src)
+		s.storeElem(obj, f != nil && f.Always)
+	case *types.Var:
+		s.storeElem(obj, s.containers[src])
+	default:
+		s.storeElem(obj, false)
+	}
+}
+
+// funcVarCall reports whether call — of a function variable — returns a
+// value with context; ok is false when call is not a function variable call.
+func (s *state) funcVarCall(call *ast.CallExpr) (has, ok bool) {
+	v, isVar := s.objectFromExpr(call.Fun).(*types.Var)
+	if !isVar || s.funcResultKind(v.Type()) == trackNone {
+		return false, false
+	}
+	if s.containers[v] {
+		s.note(call.Pos(), "every function assigned to %s returns a value with context", v.Name())
+		return true, true
+	}
+	s.note(call.Pos(), "not every function assigned to %s returns a value with context", v.Name())
+	return false, true
+}
@@ -0,0 +1,83 @@
+package zerologctx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// GlobalCtxLoggerAnalyzer is the globalctxlogger analyzer: it reports
+// loggers with context installed as zerolog's global loggers. It runs the
+// zerolog engine for the facts the assigned value is judged by.
+var GlobalCtxLoggerAnalyzer = &analysis.Analyzer{
+	Name: "globalctxlogger",
+	Doc: `Reports zerolog loggers with context installed as global loggers.
+This analyzer reports assignments of a logger carrying a context to
+log.Logger or zerolog.DefaultContextLogger. The context of one call, usually
+one request's, would then be attached to every entry logged through
+log.Info() and the like, or through every logger zerolog.Ctx returns for a
+context without one.`,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	Run:        runGlobals,
+	ResultType: reflect.TypeFor[*Result](),
+	FactTypes:  []analysis.Fact{new(globalResultFact)},
+}
+
+// globalResultFact is GlobalCtxLoggerAnalyzer's ctxResultFact.
+type globalResultFact ctxResultFact
+
+func (*globalResultFact) AFact() {}
+
+func (f *globalResultFact) String() string { return (*ctxResultFact)(f).String() }
+
+// runGlobals is the globalctxlogger entry point. The analyzer has no flags.
+func runGlobals(pass *analysis.Pass) (any, error) {
+	return runProfile(pass, zerologProfile{}, &settings{}, checkGlobals)
+}
+
+// globalLogger returns the name of obj as printed in diagnostics when it is
+// one of the library's global loggers, or "".
+func (s *state) globalLogger(obj types.Object) string {
+	v, ok := obj.(*types.Var)
+	if !ok || s.prof == nil {
+		return ""
+	}
+	return s.prof.globalLogger(v)
+}
+
+// checkGlobalAssign reports the assignment of a logger with context to one
+// of the library's global loggers: `log.Logger = log.With().Ctx(ctx).Logger()`
+// attaches the context of one call — usually one request's — to every entry
+// logged through log.Info() and the like, and DefaultContextLogger to every
+// logger zerolog.Ctx returns for a context without one. The report is
+// subject to //nolint:globalctxlogger like the other diagnostics.
+func (s *state) checkGlobalAssign(node *ast.AssignStmt) {
+	if len(node.Lhs) != len(node.Rhs) {
+		return
+	}
+	for i, lhs := range node.Lhs {
+		obj := s.objectFromExpr(lhs)
+		name := s.globalLogger(obj)
+		if name == "" || !s.exprHasCtx(s.prof.kindOf(obj.Type()), node.Rhs[i], node.Pos()) {
+			continue
+		}
+		diag := analysis.Diagnostic{
+			Pos:      node.Pos(),
+			End:      node.End(),
+			Category: CategoryGlobalCtxLogger,
+			Message: fmt.Sprintf(
+				"logger with context assigned to the global %s - its context would be attached to every entry logged through it, not only to this call's",
+				name,
+			),
+		}
+		if c := s.noLintDirective(node, node.End(), diag.Category); c != nil {
+			s.result.Suppressed = append(s.result.Suppressed, SuppressedDiagnostic{Diagnostic: diag, Directive: c.Text})
+			continue
+		}
+		s.pass.Report(diag)
+	}
+}
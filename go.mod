@@ -4,9 +4,15 @@ go 1.26.0
 
 toolchain go1.26.1
 
-require golang.org/x/tools v0.48.0
+require (
+	github.com/rs/zerolog v1.35.1
+	golang.org/x/tools v0.48.0
+)
 
 require (
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
 	golang.org/x/mod v0.38.0 // indirect
 	golang.org/x/sync v0.22.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
 )
@@ -0,0 +1,259 @@
+package zerologctx
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Interface methods returning a tracked value (`type Logger interface {
+// Info() *zerolog.Event }`) have no body to summarise. A call through one
+// has context when every implementation of the method the analysis can see
+// has it, judged by their result summaries: the concrete types declared in
+// the package and package-level ones of its dependencies, except the
+// library's own. An implementation whose method is promoted from an
+// embedded library type has no summary, and so no context.
+//
+// Packages the analysis cannot see may implement an exported interface, so
+// one is only trusted when every implementation found is declared in the
+// interface's own package, and only that package and the dependencies
+// importing it are searched. An unexported interface can only be named in
+// its package, but the types of any dependency may be converted to it, so
+// all of them are searched, and the interface is trusted on the
+// implementations found only when every dependency could be searched.
+
+// ifaceMethod keys the implementations of a method of an interface, looked
+// up in the types seen from the interface's package.
+type ifaceMethod struct {
+	iface  *types.Interface
+	fn     *types.Func
+	home   *types.Package
+	sealed bool
+}
+
+// typeScope is where concreteTypes searches: every dependency for a sealed
+// interface, or home and the dependencies importing it.
+type typeScope struct {
+	home   *types.Package
+	sealed bool
+}
+
+// typeSet is the result of concreteTypes: the types found, and whether
+// every package searched could be searched in full.
+type typeSet struct {
+	types    []*types.TypeName
+	complete bool
+}
+
+// ifaceMethodCall returns the interface method call invokes and the
+// interface it is called through, or nil when call is not an interface
+// method call on an untracked receiver. Type parameters tracked through
+// their constraint (see isNamed) are left to the predicates.
+func (s *state) ifaceMethodCall(call *ast.CallExpr) (*types.Func, types.Type, *types.Interface) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || s.recvKind(sel) != trackNone {
+		return nil, nil, nil
+	}
+	selection, ok := s.pass.TypesInfo.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return nil, nil, nil
+	}
+	fn, ok := selection.Obj().(*types.Func)
+	if !ok {
+		return nil, nil, nil
+	}
+	t := s.pass.TypesInfo.TypeOf(sel.X)
+	var iface *types.Interface
+	switch u := t.(type) {
+	case *types.TypeParam:
+		iface, _ = u.Constraint().Underlying().(*types.Interface)
+	default:
+		iface, _ = t.Underlying().(*types.Interface)
+	}
+	if iface == nil {
+		return nil, nil, nil
+	}
+	return fn, t, iface
+}
+
+// ifaceCallHasCtx reports whether the interface method call — of fn,
+// through iface — returns a value with context: every implementation's
+// summary says so for the call's arguments.
+func (s *state) ifaceCallHasCtx(call *ast.CallExpr, fn *types.Func, t types.Type, iface *types.Interface, at token.Pos) bool {
+	home, sealed := ifaceHome(t, fn)
+	if sealed && !s.concreteTypes(typeScope{home, sealed}).complete {
+		s.note(call.Pos(), "the dependencies' types cannot all be searched for implementations of %s", s.ifaceMethodString(t, fn))
+		return false
+	}
+	impls := s.implementations(ifaceMethod{iface, fn, home, sealed})
+	if len(impls) == 0 {
+		s.note(call.Pos(), "no implementation of %s is known", s.ifaceMethodString(t, fn))
+		return false
+	}
+	if !sealed {
+		for _, m := range impls {
+			if m.Pkg() != home {
+				s.note(m.Pos(), "%s implements %s outside the interface's package; other packages may too", m.FullName(), s.ifaceMethodString(t, fn))
+				return false
+			}
+		}
+	}
+	for _, m := range impls {
+		f := s.ctxResultOf(m)
+		if f == nil || !s.summaryHolds(f, call, at) {
+			s.note(m.Pos(), "%s, implementing %s, does not return a value with context", m.FullName(), s.ifaceMethodString(t, fn))
+			return false
+		}
+	}
+	s.note(call.Pos(), "every implementation of %s returns a value with context", s.ifaceMethodString(t, fn))
+	return true
+}
+
+// ifaceHome returns the package declaring the interface t, whose method fn
+// is called, and whether the interface is sealed: unexported or declared in
+// a function, so that no other package can name it. For a type parameter,
+// its constraint is the interface.
+func ifaceHome(t types.Type, fn *types.Func) (*types.Package, bool) {
+	if tp, ok := t.(*types.TypeParam); ok {
+		t = tp.Constraint()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return fn.Pkg(), false
+	}
+	obj := named.Obj()
+	return obj.Pkg(), !obj.Exported() || obj.Parent() != obj.Pkg().Scope()
+}
+
+// implementations returns the methods implementing key.fn, a method of
+// key.iface, in the types the analysis can see from key.home. Results are
+// cached.
+func (s *state) implementations(key ifaceMethod) []*types.Func {
+	if impls, ok := s.ifaceImpls[key]; ok {
+		return impls
+	}
+	var impls []*types.Func
+	for _, tn := range s.concreteTypes(typeScope{key.home, key.sealed}).types {
+		var t types.Type = tn.Type()
+		if !types.Implements(t, key.iface) {
+			t = types.NewPointer(t)
+			if !types.Implements(t, key.iface) {
+				continue
+			}
+		}
+		if m, ok := lookupMethod(t, key.fn); ok {
+			impls = append(impls, m)
+		}
+	}
+	s.ifaceImpls[key] = impls
+	return impls
+}
+
+// lookupMethod returns t's method implementing fn.
+func lookupMethod(t types.Type, fn *types.Func) (*types.Func, bool) {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, fn.Pkg(), fn.Name())
+	m, ok := obj.(*types.Func)
+	return m, ok
+}
+
+// concreteTypes returns (building lazily) the non-generic, non-interface
+// named types declared in the package, at any scope, and at package scope
+// in the dependencies of scope, excluding the library's packages. For a
+// sealed interface every dependency is searched; otherwise only home and
+// the dependencies importing it, and only dependencies that reach home are
+// walked, so the standard library is skipped unless home is part of it.
+// The set is incomplete when a searched package's scope is not complete.
+func (s *state) concreteTypes(scope typeScope) typeSet {
+	if ts, ok := s.typeNames[scope]; ok {
+		return ts
+	}
+	home := scope.home
+	tns := []*types.TypeName{}
+	complete := true
+	add := func(obj types.Object) {
+		tn, ok := obj.(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			return
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+			return
+		}
+		tns = append(tns, tn)
+	}
+	for _, obj := range s.pass.TypesInfo.Defs {
+		add(obj)
+	}
+	reaches := map[*types.Package]bool{}
+	var reachesHome func(p *types.Package) bool
+	reachesHome = func(p *types.Package) bool {
+		if p == home || scope.sealed {
+			return true
+		}
+		r, ok := reaches[p]
+		if !ok {
+			r = slices.ContainsFunc(p.Imports(), reachesHome)
+			reaches[p] = r
+		}
+		return r
+	}
+	seen := map[*types.Package]bool{s.pass.Pkg: true}
+	var visit func(p *types.Package)
+	visit = func(p *types.Package) {
+		for _, imp := range p.Imports() {
+			if seen[imp] || inPackage(imp.Path(), s.prof.pkgPath()) || !reachesHome(imp) {
+				continue
+			}
+			seen[imp] = true
+			if scope.sealed || imp == home || slices.Contains(imp.Imports(), home) {
+				complete = complete && imp.Complete()
+				for _, name := range imp.Scope().Names() {
+					add(imp.Scope().Lookup(name))
+				}
+			}
+			visit(imp)
+		}
+	}
+	visit(s.pass.Pkg)
+	ts := typeSet{tns, complete}
+	s.typeNames[scope] = ts
+	return ts
+}
+
+// inPackage reports whether path is pkgPath or one of its sub-packages.
+func inPackage(path, pkgPath string) bool {
+	return path == pkgPath || strings.HasPrefix(path, pkgPath+"/")
+}
+
+// ifaceOrigin returns the interface method the chain expr starts from, as
+// printed in diagnostics (Logger.Info), or "" when it starts elsewhere.
+func (s *state) ifaceOrigin(expr ast.Expr) string {
+	for {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			return ""
+		}
+		if fn, t, _ := s.ifaceMethodCall(call); fn != nil {
+			return s.ifaceMethodString(t, fn)
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok || s.recvKind(sel) == trackNone {
+			return ""
+		}
+		expr = sel.X
+	}
+}
+
+// ifaceMethodString prints fn called through t as written in the package:
+// Logger.Info, or applog.Logger.Info.
+func (s *state) ifaceMethodString(t types.Type, fn *types.Func) string {
+	qualifier := func(p *types.Package) string {
+		if p == s.pass.Pkg {
+			return ""
+		}
+		return p.Name()
+	}
+	return types.TypeString(t, qualifier) + "." + fn.Name()
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestStubsUpToDate regenerates the stubs from the zerolog version in go.mod
+// and compares them with the checked-in copies. Run go generate from the
+// module root to update them.
+func TestStubsUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+	stubs, err := generateAll(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{"testdata/src", "zerologctxtest/testdata/stubs"} {
+		for file, want := range stubs {
+			path := filepath.Join(root, filepath.FromSlash(dir), filepath.FromSlash(file))
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s is stale; run go generate from the module root", path)
+			}
+		}
+	}
+}
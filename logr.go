@@ -0,0 +1,89 @@
+package zerologctx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// logrPkgPath is the import path of the go-logr logging API.
+const logrPkgPath = "github.com/go-logr/logr"
+
+// LogrAnalyzer is the logrctx analyzer: Analyzer's rule for
+// github.com/go-logr/logr, where the context convention is to log through the
+// logger stored in the context (logr.FromContextOrDiscard(ctx)).
+var LogrAnalyzer = newProfileAnalyzer("logrctx", `Ensures go-logr loggers are taken from the context when one is available.
+This analyzer reports Info() and Error() calls on a logr.Logger that was not
+obtained from the context — via logr.FromContextOrDiscard(ctx) or
+logr.FromContext(ctx), possibly derived with WithValues, WithName or V — when
+a context.Context is available at the call site (a function parameter, a
+local variable declared before the call, a package-level variable, or a
+context-typed field of the method's receiver). Calls inside the functions
+listed by -allow-funcs are not reported.`, logrProfile{}, &logrSettings)
+
+// logrProfile describes go-logr: logr.Logger is the only tracked type, Info
+// and Error emit, and the FromContext lookups attach the context. Loggers
+// derived from a tracked one (WithValues, WithName, V, ...) keep it.
+type logrProfile struct{}
+
+func (logrProfile) pkgPath() string      { return logrPkgPath }
+func (logrProfile) importsContext() bool { return true }
+
+func (logrProfile) kindOf(t types.Type) trackKind {
+	if isNamed(t, logrPkgPath, "Logger") {
+		return trackLogger
+	}
+	return trackNone
+}
+
+func (logrProfile) isTerminal(k trackKind, name string) bool {
+	return k == trackLogger && (name == "Info" || name == "Error")
+}
+
+func (logrProfile) attaches(call *ast.CallExpr, fn *types.Func, recv trackKind, isCtx func(ast.Expr) bool, callee func(*ast.CallExpr) *types.Func) attachResult {
+	if recv != trackNone || fn.Pkg() == nil || fn.Pkg().Path() != logrPkgPath {
+		return attachNone
+	}
+	if fn.Name() != "FromContextOrDiscard" && fn.Name() != "FromContext" {
+		return attachNone
+	}
+	if len(call.Args) == 1 && isCtx(call.Args[0]) {
+		return attachCtx
+	}
+	return attachNonCtx
+}
+
+// storesLogger and loadsLogger return nil: logr.FromContext already counts
+// as attaching the context.
+func (logrProfile) storesLogger(*ast.CallExpr, *types.Func, trackKind) ast.Expr { return nil }
+func (logrProfile) loadsLogger(*ast.CallExpr, *types.Func, trackKind) ast.Expr  { return nil }
+
+// updater returns nil: logr.Logger has no in-place updates.
+func (logrProfile) updater(*ast.CallExpr, *types.Func, trackKind) ast.Expr { return nil }
+
+// globalLogger returns "": logr has no global logger.
+func (logrProfile) globalLogger(*types.Var) string { return "" }
+
+func (logrProfile) missingCtx(call *ast.CallExpr, sel *ast.SelectorExpr, ctxName string) analysis.Diagnostic {
+	return analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf(
+			"logr %s() called on a logger that does not come from the context - use logr.FromContextOrDiscard(ctx) for proper log correlation",
+			sel.Sel.Name,
+		),
+	}
+}
+
+func (logrProfile) nonCtxArg(call *ast.CallExpr, sel *ast.SelectorExpr) analysis.Diagnostic {
+	return analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf(
+			"logr %s() called on a logger looked up with a non-context argument - pass a context.Context for proper log correlation",
+			sel.Sel.Name,
+		),
+	}
+}
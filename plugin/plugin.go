@@ -1,6 +1,14 @@
 // Package main is the golangci-lint custom-linter plugin entry point for
 // zerologctx. It is built as a Go plugin (`-buildmode=plugin`) and loaded by
-// golangci-lint v1's linters-settings.custom mechanism.
+// golangci-lint v1's linters-settings.custom mechanism. It exposes the
+// zerologctx analyzer, its counterparts for log/slog (slogctx), logr
+// (logrctx) and zap (zapctx), and the undecoratedctx and globalctxlogger
+// checks.
+//
+// golangci-lint v1 passes no settings to plugins, so the analyzers are
+// configured from the same .zerologctx.yml policy file the standalone command
+// discovers: the one in golangci-lint's working directory or one of its
+// parents up to the module root.
 package main
 
 import (
@@ -13,7 +21,24 @@ import (
 // entry point consumed by golangci-lint's plugin loader and must keep this
 // exact name and signature.
 func GetAnalyzers() []*analysis.Analyzer {
-	return []*analysis.Analyzer{
-		zerologctx.Analyzer,
+	analyzers := zerologctx.Analyzers()
+	if err := loadConfig("."); err != nil {
+		// The loader offers no error channel; fail every pass instead, so
+		// a broken policy file is reported rather than silently ignored.
+		for i, a := range analyzers {
+			broken := *a
+			broken.Run = func(*analysis.Pass) (any, error) { return nil, err }
+			analyzers[i] = &broken
+		}
 	}
+	return analyzers
+}
+
+// loadConfig applies the policy file discovered from dir, if any.
+func loadConfig(dir string) error {
+	path, err := zerologctx.FindConfig(dir)
+	if err != nil || path == "" {
+		return err
+	}
+	return zerologctx.LoadConfig(path)
 }
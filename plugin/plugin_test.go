@@ -6,23 +6,27 @@ import (
 	"testing"
 
 	"github.com/tolmachov/zerologctx"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
-// TestGetAnalyzers verifies the plugin entry point's contract: returns
-// exactly one analyzer and it is the zerologctx analyzer.
+// TestGetAnalyzers verifies the plugin entry point's contract: it returns
+// the zerologctx analyzer followed by slogctx, logrctx, zapctx,
+// undecoratedctx and globalctxlogger.
 func TestGetAnalyzers(t *testing.T) {
 	analyzers := GetAnalyzers()
 
-	if len(analyzers) != 1 {
-		t.Fatalf("GetAnalyzers() returned %d analyzers, want 1", len(analyzers))
+	want := []*analysis.Analyzer{
+		zerologctx.Analyzer, zerologctx.SlogAnalyzer, zerologctx.LogrAnalyzer, zerologctx.ZapAnalyzer,
+		zerologctx.UndecoratedCtxAnalyzer, zerologctx.GlobalCtxLoggerAnalyzer,
 	}
-	if analyzers[0] != zerologctx.Analyzer {
-		t.Errorf("GetAnalyzers() returned wrong analyzer instance, got %v",
-			analyzers[0].Name)
+	if len(analyzers) != len(want) {
+		t.Fatalf("GetAnalyzers() returned %d analyzers, want %d", len(analyzers), len(want))
 	}
-	if analyzers[0].Name != "zerologctx" {
-		t.Errorf("analyzer name = %q, want %q", analyzers[0].Name, "zerologctx")
+	for i, a := range analyzers {
+		if a != want[i] {
+			t.Errorf("GetAnalyzers()[%d] = %q, want the %q instance", i, a.Name, want[i].Name)
+		}
 	}
 }
 
@@ -47,4 +51,17 @@ func TestPluginAnalyzerEndToEnd(t *testing.T) {
 	testdata := filepath.Join(filepath.Dir(thisFile), "..", "testdata")
 
 	analysistest.Run(t, testdata, analyzers[0], "testpkg", "logonlypkg")
+	analysistest.Run(t, testdata, analyzers[1], "slogpkg")
+	analysistest.Run(t, testdata, analyzers[2], "logrpkg")
+
+	// zappkg's own field helper is listed by -ctx-fields, as in the
+	// package's tests.
+	ctxFields := analyzers[3].Flags.Lookup("ctx-fields").Value
+	prev := ctxFields.String()
+	if err := ctxFields.Set("zappkg.ctxField"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ctxFields.Set(prev) })
+	analysistest.Run(t, testdata, analyzers[3], "zappkg")
+	analysistest.Run(t, testdata, analyzers[5], "globalpkg")
 }
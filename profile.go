@@ -0,0 +1,421 @@
+package zerologctx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+)
+
+// libraryProfile is what the engine needs to know about one logging library:
+// which of its types to track, which calls emit a log entry, which calls
+// attach a context, and how to word the diagnostics. Everything else — fact
+// collection, the context search, nolint handling, the Result — is shared.
+//
+// zerolog maps its Logger, Event and Context (builder) types to the three
+// track kinds; libraries without events or builders use trackLogger only.
+type libraryProfile interface {
+	// pkgPath is the library's import path. Packages that import neither it
+	// nor one of its sub-packages are skipped.
+	pkgPath() string
+
+	// importsContext reports whether the library itself imports "context",
+	// so that failing to find context.Context next to it means the driver
+	// served an incomplete import graph.
+	importsContext() bool
+
+	// kindOf classifies a type of the library, or returns trackNone.
+	kindOf(t types.Type) trackKind
+
+	// isTerminal reports whether calling the named method on a value of
+	// kind k emits a log entry.
+	isTerminal(k trackKind, name string) bool
+
+	// attaches reports whether call — a call of fn, a method on a value of
+	// kind recv or a package function (recv == trackNone) — attaches a
+	// context to the value it returns (or, for a terminal call, to the entry
+	// it emits). isCtx reports whether an argument satisfies
+	// context.Context; callee resolves the function an argument calls, or
+	// returns nil.
+	attaches(call *ast.CallExpr, fn *types.Func, recv trackKind, isCtx func(ast.Expr) bool, callee func(*ast.CallExpr) *types.Func) attachResult
+
+	// storesLogger returns the logger that call — a call of fn on a value of
+	// kind recv, or a package function — stores in the context it returns,
+	// or nil.
+	storesLogger(call *ast.CallExpr, fn *types.Func, recv trackKind) ast.Expr
+
+	// loadsLogger returns the context argument of call when it returns the
+	// logger stored in that context, or nil.
+	loadsLogger(call *ast.CallExpr, fn *types.Func, recv trackKind) ast.Expr
+
+	// updater returns the function argument through which call — a call of
+	// fn on a value of kind recv — rebuilds the receiver logger in place
+	// from a builder, or nil when call does not update its receiver.
+	updater(call *ast.CallExpr, fn *types.Func, recv trackKind) ast.Expr
+
+	// globalLogger returns the name of v as printed in diagnostics
+	// (log.Logger) when v is one of the library's global loggers, or "".
+	globalLogger(v *types.Var) string
+
+	// missingCtx builds the diagnostic for a terminal call without context;
+	// ctxName is the context available at the call site.
+	missingCtx(call *ast.CallExpr, sel *ast.SelectorExpr, ctxName string) analysis.Diagnostic
+
+	// nonCtxArg builds the diagnostic for a terminal call whose chain passes
+	// a non-context value (an untyped nil) to an attaching call.
+	nonCtxArg(call *ast.CallExpr, sel *ast.SelectorExpr) analysis.Diagnostic
+}
+
+// attachResult is a libraryProfile's verdict on a call.
+type attachResult uint8
+
+const (
+	// attachNone: the call does not attach a context.
+	attachNone attachResult = iota
+	// attachCtx: the call attaches a context.Context.
+	attachCtx
+	// attachNonCtx: the call is an attaching call, but its argument does not
+	// satisfy context.Context.
+	attachNonCtx
+)
+
+// newProfileAnalyzer returns an analyzer reporting the calls without context
+// of the library described by prof, with the settings cfg.
+func newProfileAnalyzer(name, doc string, prof libraryProfile, cfg *settings) *analysis.Analyzer {
+	a := &analysis.Analyzer{
+		Name:       name,
+		Doc:        doc,
+		Requires:   []*analysis.Analyzer{inspect.Analyzer},
+		Run:        func(pass *analysis.Pass) (any, error) { return runProfile(pass, prof, cfg, checkCalls) },
+		ResultType: reflect.TypeFor[*Result](),
+	}
+	return a
+}
+
+// The stubs of github.com/rs/zerolog under testdata are generated from the
+// version in go.mod.
+//go:generate go run ./internal/stubgen testdata/src zerologctxtest/testdata/stubs
+
+// zerologProfile describes github.com/rs/zerolog: Ctx(ctx) on an Event or on
+// a logger builder attaches the context, and the Event terminals emit.
+type zerologProfile struct{}
+
+// terminalMethods are the *zerolog.Event methods that produce output and must
+// be preceded by Ctx() somewhere in the chain. Keep in sync with zerolog's
+// Event terminals; non-terminal methods (Str, Int, Dict, Discard, ...) must
+// not appear here.
+var terminalMethods = map[string]struct{}{
+	"Msg":     {}, // log.Info().Msg("message")
+	"Msgf":    {}, // log.Info().Msgf("message %d", 42)
+	"MsgFunc": {}, // log.Info().MsgFunc(func() string { return "message" })
+	"Send":    {}, // log.Info().Send()
+}
+
+func (zerologProfile) pkgPath() string      { return zerologPkgPath }
+func (zerologProfile) importsContext() bool { return true }
+
+func (zerologProfile) kindOf(t types.Type) trackKind {
+	switch {
+	case isZerologLogger(t):
+		return trackLogger
+	case isZerologEvent(t):
+		return trackEvent
+	case isZerologContext(t):
+		return trackBuilder
+	}
+	return trackNone
+}
+
+func (zerologProfile) isTerminal(k trackKind, name string) bool {
+	_, ok := terminalMethods[name]
+	return ok && k == trackEvent
+}
+
+// attaches recognises Event.Ctx and Context.Ctx. The receiver requirement is
+// load-bearing: Logger lookups such as log.Ctx(ctx) or zerolog.Ctx(ctx) are
+// package functions and do NOT attach the context to created events.
+func (zerologProfile) attaches(call *ast.CallExpr, fn *types.Func, recv trackKind, isCtx func(ast.Expr) bool, callee func(*ast.CallExpr) *types.Func) attachResult {
+	if fn.Name() != "Ctx" || (recv != trackEvent && recv != trackBuilder) {
+		return attachNone
+	}
+	if len(call.Args) == 1 && isCtx(call.Args[0]) {
+		return attachCtx
+	}
+	return attachNonCtx
+}
+
+// updater recognises Logger.UpdateContext, whose function argument maps the
+// logger's builder to the one the logger is rebuilt from.
+func (zerologProfile) updater(call *ast.CallExpr, fn *types.Func, recv trackKind) ast.Expr {
+	if !isUpdateMethod(recv, fn.Name()) || len(call.Args) != 1 {
+		return nil
+	}
+	return call.Args[0]
+}
+
+// storesLogger recognises Logger.WithContext.
+func (zerologProfile) storesLogger(call *ast.CallExpr, fn *types.Func, recv trackKind) ast.Expr {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || recv != trackLogger || fn.Name() != "WithContext" {
+		return nil
+	}
+	return sel.X
+}
+
+// loadsLogger recognises zerolog.Ctx and log.Ctx.
+func (zerologProfile) loadsLogger(call *ast.CallExpr, fn *types.Func, recv trackKind) ast.Expr {
+	if recv != trackNone || fn.Name() != "Ctx" || fn.Pkg() == nil || len(call.Args) != 1 {
+		return nil
+	}
+	if p := fn.Pkg().Path(); p != zerologPkgPath && p != zerologPkgPath+"/log" {
+		return nil
+	}
+	return call.Args[0]
+}
+
+// globalLogger recognises log.Logger, behind the log package's functions,
+// and zerolog.DefaultContextLogger, returned by zerolog.Ctx for a context
+// without a logger.
+func (zerologProfile) globalLogger(v *types.Var) string {
+	if v.Pkg() == nil || v.Parent() != v.Pkg().Scope() {
+		return ""
+	}
+	switch p, name := v.Pkg().Path(), v.Name(); {
+	case p == zerologPkgPath+"/log" && name == "Logger":
+		return "log.Logger"
+	case p == zerologPkgPath && name == "DefaultContextLogger":
+		return "zerolog.DefaultContextLogger"
+	}
+	return ""
+}
+
+// isUpdateMethod reports whether the named method of a value of kind recv is
+// Logger.UpdateContext.
+func isUpdateMethod(recv trackKind, name string) bool {
+	return recv == trackLogger && name == "UpdateContext"
+}
+
+// methodClass is how the engine treats a method of one of zerolog's tracked
+// types. TestZerologMethodsClassified requires every exported Event, Logger
+// and Context method of the zerolog version in go.mod to have one, so a
+// method added upstream is a conscious decision rather than a silent gap.
+type methodClass uint8
+
+const (
+	methodUnclassified methodClass = iota
+	// methodTerminal emits the entry and is checked (Event.Msg).
+	methodTerminal
+	// methodAttach attaches a context (Event.Ctx, Context.Ctx).
+	methodAttach
+	// methodChain returns a value of the receiver's kind that keeps its
+	// context (Event.Str, Context.Str).
+	methodChain
+	// methodUpdate rebuilds the receiver in place from the builder its
+	// function argument returns (Logger.UpdateContext).
+	methodUpdate
+	// methodDerive returns a value of another tracked kind that keeps the
+	// receiver's context (Logger.Info, Logger.With, Context.Logger).
+	methodDerive
+	// methodFresh returns a tracked value unrelated to the receiver, which
+	// never has a context (Context.CreateDict).
+	methodFresh
+	// methodNeutral returns nothing tracked and neither emits an entry nor
+	// changes a context.
+	methodNeutral
+	// methodUnchecked emits an entry the analyzer does not check
+	// (Logger.Print, Logger.Write).
+	methodUnchecked
+)
+
+func (c methodClass) String() string {
+	switch c {
+	case methodTerminal:
+		return "terminal"
+	case methodAttach:
+		return "attach"
+	case methodChain:
+		return "chain"
+	case methodUpdate:
+		return "update"
+	case methodDerive:
+		return "derive"
+	case methodFresh:
+		return "fresh"
+	case methodNeutral:
+		return "neutral"
+	case methodUnchecked:
+		return "unchecked"
+	}
+	return "unclassified"
+}
+
+// zerologFollowed lists, per receiver kind, the result kinds the predicates
+// follow back to the receiver: eventCtxSource through Event methods and
+// Event-creating Logger methods, loggerHasCtx through Logger derivations and
+// builder.Logger(), builderHasCtx through builder methods and
+// logger.With().
+var zerologFollowed = map[trackKind][]trackKind{
+	trackEvent:   {trackEvent},
+	trackLogger:  {trackEvent, trackLogger, trackBuilder},
+	trackBuilder: {trackBuilder, trackLogger},
+}
+
+// zerologOtherMethods classifies the methods whose result the predicates do
+// not follow.
+var zerologOtherMethods = map[trackKind]map[string]methodClass{
+	trackEvent: {
+		"CreateArray": methodNeutral,
+		"Enabled":     methodNeutral,
+		"GetCtx":      methodNeutral,
+	},
+	trackLogger: {
+		"GetLevel":    methodNeutral,
+		"WithContext": methodNeutral, // stores the logger in a context; see -ctx-loggers
+		"Print":       methodUnchecked,
+		"Printf":      methodUnchecked,
+		"Println":     methodUnchecked,
+		"Write":       methodUnchecked, // io.Writer: p is logged as is
+	},
+	trackBuilder: {
+		"CreateArray": methodNeutral,
+		"CreateDict":  methodFresh,
+	},
+}
+
+// classify reports how the engine treats fn, a method of a type of kind
+// recv.
+func (p zerologProfile) classify(recv trackKind, fn *types.Func) methodClass {
+	name := fn.Name()
+	switch {
+	case p.isTerminal(recv, name):
+		return methodTerminal
+	case name == "Ctx" && (recv == trackEvent || recv == trackBuilder):
+		return methodAttach
+	case isUpdateMethod(recv, name):
+		return methodUpdate
+	}
+	if c, ok := zerologOtherMethods[recv][name]; ok {
+		return c
+	}
+	if res := fn.Signature().Results(); res.Len() == 1 {
+		k := p.kindOf(res.At(0).Type())
+		switch {
+		case k == trackNone:
+		case k == recv:
+			return methodChain
+		case slices.Contains(zerologFollowed[recv], k):
+			return methodDerive
+		}
+	}
+	return methodUnclassified
+}
+
+func (zerologProfile) missingCtx(call *ast.CallExpr, sel *ast.SelectorExpr, ctxName string) analysis.Diagnostic {
+	return analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf(
+			"zerolog event missing .Ctx(ctx) before %s() - context should be included for proper log correlation",
+			sel.Sel.Name,
+		),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("Insert .Ctx(%s) before %s()", ctxName, sel.Sel.Name),
+			TextEdits: []analysis.TextEdit{{
+				Pos:     sel.Sel.Pos(),
+				End:     sel.Sel.Pos(),
+				NewText: []byte("Ctx(" + ctxName + ")."),
+			}},
+		}},
+	}
+}
+
+// nonCtxArg words the report for Ctx(nil): with the real zerolog API only an
+// untyped nil can reach a Ctx() call without satisfying context.Context, and
+// the message must not falsely claim the Ctx() call is missing.
+func (zerologProfile) nonCtxArg(call *ast.CallExpr, sel *ast.SelectorExpr) analysis.Diagnostic {
+	return analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf(
+			"zerolog event calls Ctx() with a non-context argument before %s() - pass a context.Context for proper log correlation",
+			sel.Sel.Name,
+		),
+	}
+}
+
+// isZerologNamed reports whether t (or its pointer element) is the named type
+// zerologPkgPath.name. Comparing the defining package path avoids matching
+// similarly named types from forks or unrelated packages.
+func isZerologNamed(t types.Type, name string) bool {
+	return isNamed(t, zerologPkgPath, name)
+}
+
+func isZerologEvent(t types.Type) bool   { return isZerologNamed(t, "Event") }
+func isZerologLogger(t types.Type) bool  { return isZerologNamed(t, "Logger") }
+func isZerologContext(t types.Type) bool { return isZerologNamed(t, "Context") }
+
+// isNamed reports whether t (or its pointer element) is the named type
+// pkgPath.name. A type parameter is when every type of its constraint's type
+// set is: L of func f[L interface{ *zerolog.Logger }](l L).
+func isNamed(t types.Type, pkgPath, name string) bool {
+	if t == nil {
+		return false
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if tp, ok := t.(*types.TypeParam); ok {
+		terms := constraintTerms(tp)
+		for _, term := range terms {
+			if !isNamed(term, pkgPath, name) {
+				return false
+			}
+		}
+		return len(terms) > 0
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	if obj == nil || obj.Pkg() == nil {
+		return false
+	}
+	return obj.Name() == name && obj.Pkg().Path() == pkgPath
+}
+
+// constraintTerms returns the types listed by tp's constraint, or nil when
+// it lists none (any, or an interface of methods only). Embedded method-only
+// interfaces narrow the type set without listing types and are skipped.
+func constraintTerms(tp *types.TypeParam) []types.Type {
+	iface, ok := tp.Constraint().Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	var terms []types.Type
+	for i := range iface.NumEmbeddeds() {
+		switch e := iface.EmbeddedType(i).(type) {
+		case *types.Union:
+			for j := range e.Len() {
+				terms = append(terms, e.Term(j).Type())
+			}
+		default:
+			if _, ok := e.Underlying().(*types.Interface); !ok {
+				terms = append(terms, e)
+			}
+		}
+	}
+	return terms
+}
+
+// Analyzers returns every analyzer of the family — zerologctx, slogctx,
+// logrctx and zapctx, then the undecoratedctx and globalctxlogger checks — in
+// the order the bundled commands expose them. Each has its own name, which is
+// also its //nolint identifier, and its own flags.
+func Analyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{Analyzer, SlogAnalyzer, LogrAnalyzer, ZapAnalyzer, UndecoratedCtxAnalyzer, GlobalCtxLoggerAnalyzer}
+}
@@ -0,0 +1,83 @@
+package zerologctx
+
+import (
+	"go/types"
+	"os"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// TestZerologMethodsClassified enumerates every exported method of
+// zerolog's Event, Logger and Context in the version required by go.mod and
+// requires zerologProfile.classify to place it, so a method added upstream
+// fails here until the analyzer is taught how to treat it.
+func TestZerologMethodsClassified(t *testing.T) {
+	cfg := &packages.Config{
+		Mode: packages.NeedTypes,
+		Env:  append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=mod"),
+	}
+	pkgs, err := packages.Load(cfg, zerologPkgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 1 || len(pkgs[0].Errors) > 0 {
+		t.Fatalf("loading %s: %v", zerologPkgPath, pkgs[0].Errors)
+	}
+	prof := zerologProfile{}
+	got := make(map[string]methodClass)
+	for _, name := range []string{"Event", "Logger", "Context"} {
+		obj := pkgs[0].Types.Scope().Lookup(name)
+		if obj == nil {
+			t.Fatalf("zerolog.%s not found", name)
+		}
+		recv := prof.kindOf(obj.Type())
+		mset := types.NewMethodSet(types.NewPointer(obj.Type()))
+		for sel := range mset.Methods() {
+			fn := sel.Obj().(*types.Func)
+			if !fn.Exported() {
+				continue
+			}
+			c := prof.classify(recv, fn)
+			if c == methodUnclassified {
+				t.Errorf("zerolog.%s.%s%s is not classified: teach the predicates to follow its result or list it in zerologOtherMethods",
+					name, fn.Name(), types.TypeString(fn.Type(), types.RelativeTo(pkgs[0].Types))[len("func"):])
+			}
+			got[name+"."+fn.Name()] = c
+		}
+	}
+
+	// Spot checks, one or more per class.
+	for method, want := range map[string]methodClass{
+		"Event.Msg":            methodTerminal,
+		"Event.Send":           methodTerminal,
+		"Event.Ctx":            methodAttach,
+		"Context.Ctx":          methodAttach,
+		"Event.Str":            methodChain,
+		"Event.Func":           methodChain,
+		"Context.Type":         methodChain,
+		"Logger.Info":          methodDerive,
+		"Logger.WithLevel":     methodDerive,
+		"Logger.Level":         methodChain,
+		"Logger.With":          methodDerive,
+		"Context.Logger":       methodDerive,
+		"Context.CreateDict":   methodFresh,
+		"Event.Enabled":        methodNeutral,
+		"Logger.Print":         methodUnchecked,
+		"Logger.UpdateContext": methodUpdate,
+	} {
+		if got[method] != want {
+			t.Errorf("%s classified %s, want %s", method, got[method], want)
+		}
+	}
+
+	// Entries for methods zerolog no longer has are stale.
+	recvNames := map[trackKind]string{trackEvent: "Event", trackLogger: "Logger", trackBuilder: "Context"}
+	for recv, methods := range zerologOtherMethods {
+		for name := range methods {
+			if _, ok := got[recvNames[recv]+"."+name]; !ok {
+				t.Errorf("zerologOtherMethods lists %s.%s, which zerolog does not have", recvNames[recv], name)
+			}
+		}
+	}
+}
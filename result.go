@@ -0,0 +1,139 @@
+package zerologctx
+
+import (
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Result is the Analyzer's per-package result. Drivers (such as the
+// zerologctx command) read it to report what the diagnostics alone do not
+// carry.
+type Result struct {
+	// Calls classifies every terminal call (Msg, Msgf, MsgFunc, Send) on a
+	// *zerolog.Event in the package — or, for SlogAnalyzer, every slog
+	// logging call — in traversal order.
+	Calls []TerminalCall
+
+	// Suppressed lists the findings that a //nolint directive or
+	// -allow-funcs silenced, in traversal order.
+	Suppressed []SuppressedDiagnostic
+}
+
+// Diagnostic categories. Every diagnostic of the family carries one of them
+// as its Category; they are stable identifiers that tools may key on instead
+// of the message text, and a //nolint:NAME/CATEGORY directive silences only
+// that category.
+const (
+	// CategoryMissingCtx: a context is available at the call site but the
+	// call does not carry it.
+	CategoryMissingCtx = "missing-ctx"
+	// CategoryNilCtx: the call is given a context argument that does not
+	// satisfy context.Context (an untyped nil).
+	CategoryNilCtx = "nil-ctx"
+	// CategoryNoCtxAvailable: the call lacks a context, but there is none to
+	// pass. Such calls are never reported; the category only appears in
+	// TerminalCall.Category.
+	CategoryNoCtxAvailable = "no-ctx-available"
+	// CategoryUndecoratedCtx: with -require-ctx-decorators, a context passed
+	// to the logger cannot be traced to a required decorator or a parameter.
+	CategoryUndecoratedCtx = "undecorated-ctx"
+	// CategoryGlobalCtxLogger: a logger with context is installed as one of
+	// the library's global loggers (log.Logger), attaching one call's
+	// context to every entry logged through it.
+	CategoryGlobalCtxLogger = "global-ctx-logger"
+)
+
+// TerminalCall is one terminal call and what the analyzer concluded about it.
+type TerminalCall struct {
+	Pos, End token.Pos
+	// Method is the terminal method's name.
+	Method string
+	Status CallStatus
+	// Source tells where the context comes from when Status is
+	// CallHasContext, and is SourceNone otherwise.
+	Source ContextSource
+	// Category is the diagnostic category of a call that lacks a context
+	// (CategoryMissingCtx, CategoryNilCtx or CategoryNoCtxAvailable), and is
+	// empty when Status is CallHasContext.
+	Category string
+	// Explain is the reasoning behind Status, step by step, when the
+	// analyzer runs with -explain. Reported diagnostics carry the same steps
+	// as their Related information.
+	Explain []analysis.RelatedInformation
+}
+
+// CallStatus classifies a terminal call.
+type CallStatus uint8
+
+const (
+	// CallHasContext: the Event carries a context.
+	CallHasContext CallStatus = iota
+	// CallMissingContext: the Event lacks a context (or Ctx() got a
+	// non-context argument) and the call was reported.
+	CallMissingContext
+	// CallNoContext: the Event lacks a context, but none is available at
+	// the call site, so the call is not reported.
+	CallNoContext
+	// CallSuppressed: the call would have been reported but for a //nolint
+	// directive or -allow-funcs.
+	CallSuppressed
+)
+
+func (c CallStatus) String() string {
+	switch c {
+	case CallHasContext:
+		return "has-context"
+	case CallMissingContext:
+		return "missing-context"
+	case CallNoContext:
+		return "no-context-available"
+	case CallSuppressed:
+		return "suppressed"
+	}
+	return "unknown"
+}
+
+// ContextSource tells how an Event got its context.
+type ContextSource uint8
+
+const (
+	SourceNone      ContextSource = iota
+	SourceInlineCtx               // .Ctx(ctx) in the Event chain
+	SourceLogger                  // created from a context-bearing logger
+	SourceEventVar                // a tracked Event variable with context
+	SourceArgument                // passed as an argument (slog's *Context variants)
+	SourceHelper                  // returned by a function whose summary has context
+)
+
+func (c ContextSource) String() string {
+	switch c {
+	case SourceNone:
+		return "none"
+	case SourceInlineCtx:
+		return "inline-ctx"
+	case SourceLogger:
+		return "contextual-logger"
+	case SourceEventVar:
+		return "tracked-event"
+	case SourceArgument:
+		return "ctx-argument"
+	case SourceHelper:
+		return "helper-result"
+	}
+	return "unknown"
+}
+
+// SuppressedDiagnostic is a diagnostic that would have been reported but for
+// a //nolint directive or -allow-funcs.
+type SuppressedDiagnostic struct {
+	Diagnostic analysis.Diagnostic
+	// Directive is the text of the //nolint comment that applied, or
+	// AllowFuncsDirective for a call inside a function listed by
+	// -allow-funcs.
+	Directive string
+}
+
+// AllowFuncsDirective is the SuppressedDiagnostic.Directive of findings
+// silenced by -allow-funcs.
+const AllowFuncsDirective = "-allow-funcs"
@@ -0,0 +1,30 @@
+// Package ctxtest fails tests that log zerolog events without a context. It
+// is the test-only counterpart of the runtime package's Hook, kept separate
+// so that programs importing runtime do not link the testing package.
+package ctxtest
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	ctxruntime "github.com/tolmachov/zerologctx/runtime"
+)
+
+// Require installs a counting Hook on *logger for the duration of the test
+// and fails tb for each event logged through it without a context. *logger
+// is restored when the test ends; pass &log.Logger to cover the global
+// logger. The returned Hook reports the count so far.
+//
+// Loggers derived from *logger before the call (With().Logger(), Level, ...)
+// are copies and are not covered.
+func Require(tb testing.TB, logger *zerolog.Logger) *ctxruntime.Hook {
+	tb.Helper()
+	h := ctxruntime.NewHookFunc(ctxruntime.ModeCount, func(desc string) {
+		tb.Errorf("%s", desc)
+	})
+	prev := *logger
+	*logger = logger.Hook(h)
+	tb.Cleanup(func() { *logger = prev })
+	return h
+}
@@ -0,0 +1,48 @@
+package ctxtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// fakeTB records Errorf calls and runs cleanups on demand.
+type fakeTB struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRequire(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	tb := &fakeTB{}
+	h := Require(tb, &logger)
+
+	logger.Info().Ctx(context.TODO()).Msg("with context")
+	logger.Error().Msg("missing")
+	if h.Missing() != 1 || len(tb.errors) != 1 {
+		t.Fatalf("Missing() = %d, errors = %q; want one each", h.Missing(), tb.errors)
+	}
+	want := `zerologctx: error event "missing" logged without a context - call .Ctx(ctx) before the terminal method`
+	if tb.errors[0] != want {
+		t.Errorf("error = %q, want %q", tb.errors[0], want)
+	}
+
+	for _, fn := range tb.cleanups {
+		fn()
+	}
+	logger.Info().Msg("after cleanup")
+	if len(tb.errors) != 1 {
+		t.Errorf("hook still installed after cleanup: %q", tb.errors)
+	}
+}
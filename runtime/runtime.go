@@ -0,0 +1,118 @@
+// Package runtime is the run-time companion of the zerologctx analyzer: a
+// zerolog.Hook that checks each event for a context, catching what static
+// analysis cannot follow (loggers taken from maps, passed through interfaces
+// or built by reflection).
+//
+// An event has a context when Ctx(ctx) was called on it or on the builder of
+// its logger. zerolog reports an event without one as carrying
+// context.Background(), so an event given context.Background() explicitly is
+// indistinguishable from one given nothing and counts as missing.
+//
+// Like the analyzer, the hook applies to every level: any event that reaches
+// it without a context is a finding. (zerolog runs hooks only for enabled
+// events, so events filtered out by the logger's level are never seen.)
+//
+// Import it under a name that does not clash with the standard library:
+//
+//	import ctxruntime "github.com/tolmachov/zerologctx/runtime"
+//
+//	logger := log.Logger.Hook(ctxruntime.NewHook(ctxruntime.ModeMark))
+//
+// Tests use the ctxtest sub-package, whose Require fails a test for each
+// event logged without a context.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// MissingField is the field ModeMark adds to events without a context.
+const MissingField = "ctx_missing"
+
+// Mode selects what a Hook does with an event that has no context.
+type Mode uint8
+
+const (
+	// ModeCount only counts the event; see Hook.Missing.
+	ModeCount Mode = iota
+	// ModeMark counts the event and adds MissingField=true to it, so the
+	// entries can be found in the log store.
+	ModeMark
+	// ModePanic panics, for tests and development builds.
+	ModePanic
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeCount:
+		return "count"
+	case ModeMark:
+		return "mark"
+	case ModePanic:
+		return "panic"
+	}
+	return "unknown"
+}
+
+// Hook is a zerolog.Hook reporting events logged without a context. It is
+// safe for concurrent use.
+type Hook struct {
+	mode    Mode
+	missing atomic.Int64
+
+	// onMissing, when set, is called with the description of each event
+	// without a context after the mode's action.
+	onMissing func(desc string)
+}
+
+// NewHook returns a Hook acting on events without a context according to
+// mode.
+func NewHook(mode Mode) *Hook {
+	return &Hook{mode: mode}
+}
+
+// NewHookFunc is like NewHook, but the Hook also calls onMissing with a
+// description of each event without a context, after the mode's action.
+// ctxtest.Require uses it to fail the test.
+func NewHookFunc(mode Mode, onMissing func(desc string)) *Hook {
+	return &Hook{mode: mode, onMissing: onMissing}
+}
+
+// Run implements zerolog.Hook.
+func (h *Hook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	if e.GetCtx() != context.Background() {
+		return
+	}
+	h.missing.Add(1)
+	switch h.mode {
+	case ModeMark:
+		e.Bool(MissingField, true)
+	case ModePanic:
+		panic(missingMessage(level, msg))
+	}
+	if h.onMissing != nil {
+		h.onMissing(missingMessage(level, msg))
+	}
+}
+
+// Missing returns the number of events seen without a context.
+func (h *Hook) Missing() int64 {
+	return h.missing.Load()
+}
+
+// missingMessage describes an event without a context.
+func missingMessage(level zerolog.Level, msg string) string {
+	return fmt.Sprintf("zerologctx: %s event %q logged without a context - call .Ctx(ctx) before the terminal method", levelName(level), msg)
+}
+
+// levelName names level, including NoLevel, which zerolog prints as "".
+func levelName(level zerolog.Level) string {
+	if level == zerolog.NoLevel {
+		return "no-level"
+	}
+	return level.String()
+}
@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+type ctxKey struct{}
+
+func TestHookModes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "req")
+
+	t.Run("count", func(t *testing.T) {
+		h := NewHook(ModeCount)
+		var buf bytes.Buffer
+		logger := zerolog.New(&buf).Hook(h)
+		logger.Info().Ctx(ctx).Msg("with context")
+		contextual := logger.With().Ctx(ctx).Logger()
+		contextual.Warn().Msg("contextual logger")
+		logger.Info().Msg("missing")
+		logger.Log().Send()
+		if got := h.Missing(); got != 2 {
+			t.Errorf("Missing() = %d, want 2", got)
+		}
+		if strings.Contains(buf.String(), MissingField) {
+			t.Errorf("ModeCount changed the output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("mark", func(t *testing.T) {
+		h := NewHook(ModeMark)
+		var buf bytes.Buffer
+		logger := zerolog.New(&buf).Hook(h)
+		logger.Info().Ctx(ctx).Msg("with context")
+		logger.Info().Msg("missing")
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+		}
+		if strings.Contains(lines[0], MissingField) {
+			t.Errorf("event with context was marked: %s", lines[0])
+		}
+		if !strings.Contains(lines[1], `"`+MissingField+`":true`) {
+			t.Errorf("event without context was not marked: %s", lines[1])
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		logger := zerolog.New(&bytes.Buffer{}).Hook(NewHook(ModePanic))
+		logger.Info().Ctx(ctx).Msg("with context")
+		defer func() {
+			r := recover()
+			want := `zerologctx: info event "missing" logged without a context`
+			if s, _ := r.(string); !strings.HasPrefix(s, want) {
+				t.Errorf("recovered %v, want a panic starting with %q", r, want)
+			}
+		}()
+		logger.Info().Msg("missing")
+		t.Error("ModePanic did not panic")
+	})
+
+	t.Run("func", func(t *testing.T) {
+		var got []string
+		h := NewHookFunc(ModeCount, func(desc string) { got = append(got, desc) })
+		logger := zerolog.New(&bytes.Buffer{}).Hook(h)
+		logger.Info().Ctx(ctx).Msg("with context")
+		logger.Warn().Msg("missing")
+		want := `zerologctx: warn event "missing" logged without a context - call .Ctx(ctx) before the terminal method`
+		if h.Missing() != 1 || len(got) != 1 || got[0] != want {
+			t.Errorf("Missing() = %d, onMissing got %q; want one call with %q", h.Missing(), got, want)
+		}
+	})
+
+	t.Run("disabled level", func(t *testing.T) {
+		h := NewHook(ModeCount)
+		logger := zerolog.New(&bytes.Buffer{}).Level(zerolog.WarnLevel).Hook(h)
+		logger.Debug().Msg("filtered out")
+		if got := h.Missing(); got != 0 {
+			t.Errorf("Missing() = %d for a filtered event, want 0", got)
+		}
+	})
+}
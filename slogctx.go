@@ -0,0 +1,188 @@
+package zerologctx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// slogPkgPath is the import path of the standard structured logging package.
+const slogPkgPath = "log/slog"
+
+// SlogAnalyzer is the slogctx analyzer, the log/slog counterpart of
+// Analyzer. It shares the context-availability search, //nolint handling
+// (as //nolint:slogctx) and the Result classification with it, and has its
+// own -allow-funcs and -explain flags.
+var SlogAnalyzer = &analysis.Analyzer{
+	Name: "slogctx",
+	Doc: `Ensures log/slog calls pass the context when one is available.
+This analyzer reports calls to slog.Debug/Info/Warn/Error and the
+same-named *slog.Logger methods when a context.Context is available at the
+call site (a function parameter, a local variable declared before the call,
+a package-level variable, or a context-typed field of the method's receiver),
+and suggests the *Context variant (InfoContext(ctx, ...)) instead. Calls to
+the *Context variants, Log and LogAttrs with a nil context are reported too.
+Calls inside the functions listed by -allow-funcs are not reported.`,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	Run:        runSlog,
+	ResultType: reflect.TypeFor[*Result](),
+}
+
+// slogContextVariants maps the slog functions and *slog.Logger methods that
+// take no context to their context-aware variants.
+var slogContextVariants = map[string]string{
+	"Debug": "DebugContext",
+	"Info":  "InfoContext",
+	"Warn":  "WarnContext",
+	"Error": "ErrorContext",
+}
+
+// slogContextCalls are the slog functions and *slog.Logger methods whose
+// first argument is the context.
+var slogContextCalls = map[string]struct{}{
+	"DebugContext": {},
+	"InfoContext":  {},
+	"WarnContext":  {},
+	"ErrorContext": {},
+	"Log":          {},
+	"LogAttrs":     {},
+}
+
+// runSlog is the slogctx entry point.
+func runSlog(pass *analysis.Pass) (any, error) {
+	insp, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	if !ok {
+		return nil, fmt.Errorf("slogctx: inspect.Analyzer result missing or wrong type")
+	}
+	hasSlog, contextIface := scanImports(pass.Pkg, slogPkgPath)
+	if !hasSlog {
+		return &Result{}, nil
+	}
+	// log/slog imports "context"; see run.
+	if contextIface == nil {
+		return nil, fmt.Errorf("slogctx: could not locate context.Context in the import graph of %s", pass.Pkg.Path())
+	}
+	s, err := newState(pass, nil, &slogSettings, contextIface)
+	if err != nil {
+		return nil, err
+	}
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		s.handleSlogCall(n.(*ast.CallExpr))
+	})
+	if s.readErr != nil {
+		return nil, fmt.Errorf("slogctx: reading source for nolint processing: %w", s.readErr)
+	}
+	return s.result, nil
+}
+
+// handleSlogCall checks a call to a slog logging function or *slog.Logger
+// method. Calls without a context are reported when one is available, with a
+// fix rewriting them to the *Context variant; calls to a context-taking
+// variant are reported when the argument is not a context (a nil literal).
+func (s *state) handleSlogCall(node *ast.CallExpr) {
+	fn := s.slogCallee(node)
+	if fn == nil {
+		return
+	}
+	name := calleeIdent(node.Fun)
+	if name == nil {
+		return
+	}
+	variant, plain := slogContextVariants[fn.Name()]
+	if !plain && !slogTakesContext(fn) {
+		return
+	}
+
+	if s.cfg.explain {
+		s.tracing, s.trace = true, nil
+		defer func() { s.tracing, s.trace = false, nil }()
+	}
+	call := TerminalCall{Pos: node.Pos(), End: node.End(), Method: fn.Name()}
+	if !plain {
+		if s.callArgIsContext(node) {
+			s.note(node.Args[0].Pos(), "%s is passed as the context", types.ExprString(node.Args[0]))
+			call.Status, call.Source, call.Explain = CallHasContext, SourceArgument, s.trace
+			s.result.Calls = append(s.result.Calls, call)
+			return
+		}
+		pos := node.Lparen
+		if len(node.Args) > 0 {
+			pos = node.Args[0].Pos()
+		}
+		s.note(pos, "the context argument does not satisfy context.Context")
+		s.reportMissing(call, node, node.Rparen, analysis.Diagnostic{
+			Pos:      node.Pos(),
+			End:      node.End(),
+			Category: CategoryNilCtx,
+			Message: fmt.Sprintf(
+				"slog %s() called with a non-context argument - pass a context.Context for proper log correlation",
+				fn.Name(),
+			),
+		}, true)
+		return
+	}
+
+	s.note(name.Pos(), "%s() takes no context", fn.Name())
+	ctxName, ok := s.findCtxInScope(node.Pos())
+	if !ok {
+		s.reportMissing(call, node, node.Rparen, analysis.Diagnostic{}, false)
+		return
+	}
+	s.reportMissing(call, node, node.Rparen, analysis.Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Category: CategoryMissingCtx,
+		Message: fmt.Sprintf(
+			"slog %s() called without context - use %s(ctx, ...) for proper log correlation",
+			fn.Name(), variant,
+		),
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: fmt.Sprintf("Use %s(%s, ...)", variant, ctxName),
+			TextEdits: []analysis.TextEdit{
+				{Pos: name.Pos(), End: name.End(), NewText: []byte(variant)},
+				{Pos: node.Lparen + 1, End: node.Lparen + 1, NewText: []byte(ctxName + ", ")},
+			},
+		}},
+	}, true)
+}
+
+// slogCallee returns the slog function or *slog.Logger method node calls, or
+// nil when it calls something else.
+func (s *state) slogCallee(node *ast.CallExpr) *types.Func {
+	fn := typeutil.StaticCallee(s.pass.TypesInfo, node)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != slogPkgPath {
+		return nil
+	}
+	if recv := fn.Signature().Recv(); recv != nil && !isSlogLogger(recv.Type()) {
+		return nil
+	}
+	return fn
+}
+
+// slogTakesContext reports whether fn, a slog function or *slog.Logger
+// method, takes the context as its first argument.
+func slogTakesContext(fn *types.Func) bool {
+	_, ok := slogContextCalls[fn.Name()]
+	return ok
+}
+
+// calleeIdent returns the identifier naming the called function: the
+// selector of slog.Info or logger.Info, or a dot-imported Info.
+func calleeIdent(fun ast.Expr) *ast.Ident {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return f
+	case *ast.SelectorExpr:
+		return f.Sel
+	}
+	return nil
+}
+
+// isSlogLogger reports whether t is slog.Logger or *slog.Logger.
+func isSlogLogger(t types.Type) bool { return isNamed(t, slogPkgPath, "Logger") }
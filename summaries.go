@@ -0,0 +1,272 @@
+package zerologctx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// ctxResultFact summarises a function returning a tracked value (a logger,
+// an Event or a builder) for its callers, in the package and, as an
+// analysis.Fact, in other packages. Generic functions are summarised once,
+// on their origin, and the summary applies to every instantiation.
+type ctxResultFact struct {
+	// Always is set when every tracked result of every return has context.
+	Always bool
+	// Params are the indices of the tracked parameters whose context every
+	// return keeps: the result has context when the argument has.
+	Params []int
+}
+
+func (*ctxResultFact) AFact() {}
+
+func (f *ctxResultFact) String() string {
+	if f.Always {
+		return "ctxResult(always)"
+	}
+	params := make([]string, len(f.Params))
+	for i, p := range f.Params {
+		params[i] = fmt.Sprint(p)
+	}
+	return "ctxResult(params " + strings.Join(params, ", ") + ")"
+}
+
+// funcDeclMap returns (building lazily) the package's function and method
+// declarations with a body, keyed by their object.
+func (s *state) funcDeclMap() map[*types.Func]*ast.FuncDecl {
+	if s.funcDecls == nil {
+		s.funcDecls = make(map[*types.Func]*ast.FuncDecl)
+		for _, f := range s.pass.Files {
+			for _, d := range f.Decls {
+				if fd, ok := d.(*ast.FuncDecl); ok && fd.Body != nil {
+					if fn, ok := s.pass.TypesInfo.Defs[fd.Name].(*types.Func); ok {
+						s.funcDecls[fn] = fd
+					}
+				}
+			}
+		}
+	}
+	return s.funcDecls
+}
+
+// returnStmts returns the return statements of body, skipping those of
+// nested function literals.
+func returnStmts(body *ast.BlockStmt) []*ast.ReturnStmt {
+	var returns []*ast.ReturnStmt
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returns = append(returns, n)
+		}
+		return true
+	})
+	return returns
+}
+
+// updateResultSummaries recomputes the summary of every function of the
+// package from the facts collected so far and reports whether one changed,
+// for the collectFacts fixpoint.
+func (s *state) updateResultSummaries() bool {
+	changed := false
+	for fn, fd := range s.funcDeclMap() {
+		f := s.summarizeResults(fn, fd)
+		old := s.resultFuncs[fn]
+		if f == nil || old != nil && old.Always == f.Always && slices.Equal(old.Params, f.Params) {
+			continue
+		}
+		s.resultFuncs[fn] = f
+		changed = true
+	}
+	return changed
+}
+
+// summarizeResults computes fn's ctxResultFact, or nil when its results are
+// not tracked or never known to have context. A parameter is listed when
+// assuming its argument has context makes every return have context.
+func (s *state) summarizeResults(fn *types.Func, fd *ast.FuncDecl) *ctxResultFact {
+	sig := fn.Signature()
+	tracked := false
+	for v := range sig.Results().Variables() {
+		tracked = tracked || s.prof.kindOf(v.Type()) != trackNone
+	}
+	returns := returnStmts(fd.Body)
+	if !tracked || len(returns) == 0 {
+		return nil
+	}
+	if s.returnsHaveCtx(sig, returns) {
+		return &ctxResultFact{Always: true}
+	}
+	var f ctxResultFact
+	for i := range sig.Params().Len() {
+		p := sig.Params().At(i)
+		tk := s.prof.kindOf(p.Type())
+		if tk == trackNone {
+			continue
+		}
+		if s.whatIf(fd.Body, p, fd.Pos(), positiveFactFor(tk), func() bool { return s.returnsHaveCtx(sig, returns) }) {
+			f.Params = append(f.Params, i)
+		}
+	}
+	if len(f.Params) == 0 {
+		return nil
+	}
+	return &f
+}
+
+// whatIf evaluates eval with obj assumed to hold kind from pos on. The fact
+// handlers are re-run over body to a fixpoint first, so that variables
+// derived from obj see the assumption; the fact table and the pass's element
+// stores are restored afterwards.
+func (s *state) whatIf(body *ast.BlockStmt, obj types.Object, pos token.Pos, kind factKind, eval func() bool) bool {
+	stores := make(map[types.Object]*elemStores, len(s.elemStores))
+	for o, st := range s.elemStores {
+		stores[o] = &elemStores{withCtx: st.withCtx, withoutCtx: st.withoutCtx}
+	}
+	s.facts.begin()
+	defer func() {
+		s.facts.rollback()
+		s.elemStores = stores
+	}()
+
+	s.facts.set(obj, pos, kind)
+	for range maxFactPasses {
+		s.facts.dirty = false
+		ast.Inspect(body, func(n ast.Node) bool {
+			s.collectNode(n)
+			return true
+		})
+		if !s.facts.dirty {
+			break
+		}
+	}
+	return eval()
+}
+
+// returnsHaveCtx reports whether every tracked result of every return has
+// context. A bare return reads the named results; `return g()` forwarding a
+// tuple is judged by its call.
+func (s *state) returnsHaveCtx(sig *types.Signature, returns []*ast.ReturnStmt) bool {
+	results := sig.Results()
+	for _, ret := range returns {
+		for i := range results.Len() {
+			v := results.At(i)
+			tk := s.prof.kindOf(v.Type())
+			if tk == trackNone {
+				continue
+			}
+			var ok bool
+			switch {
+			case len(ret.Results) == results.Len():
+				ok = s.exprHasCtx(tk, ret.Results[i], ret.Pos())
+			case len(ret.Results) == 1: // return g()
+				ok = s.exprHasCtx(tk, ret.Results[0], ret.Pos())
+			default: // bare return of named results
+				ok = s.objFactIs(v, ret.Pos(), ret.Pos(), positiveFactFor(tk))
+			}
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ctxResultOf returns the summary of fn: computed for a function of the
+// package, or imported for one of another package.
+func (s *state) ctxResultOf(fn *types.Func) *ctxResultFact {
+	fn = fn.Origin()
+	if fn.Pkg() == s.pass.Pkg {
+		return s.resultFuncs[fn]
+	}
+	if fn.Pkg() == nil {
+		return nil
+	}
+	var f ctxResultFact
+	fact := s.resultFact(&f)
+	if fact == nil || !s.pass.ImportObjectFact(fn, fact) {
+		return nil
+	}
+	return &f
+}
+
+// resultFact returns f as the summary fact type the running analyzer
+// registers, sharing its memory, or nil when the analyzer registers none. A
+// fact type belongs to one analyzer, so each analyzer exchanging summaries
+// across packages has its own.
+func (s *state) resultFact(f *ctxResultFact) analysis.Fact {
+	for _, ft := range s.pass.Analyzer.FactTypes {
+		switch ft.(type) {
+		case *ctxResultFact:
+			return f
+		case *globalResultFact:
+			return (*globalResultFact)(f)
+		}
+	}
+	return nil
+}
+
+// summarizedCall reports whether call — of a function with a summary, of an
+// interface method or of a function variable — returns a value with
+// context; ok is false when the callee is none of these.
+func (s *state) summarizedCall(call *ast.CallExpr, at token.Pos) (has, ok bool) {
+	if has, ok := s.funcVarCall(call); ok {
+		return has, true
+	}
+	if fn, t, iface := s.ifaceMethodCall(call); fn != nil {
+		return s.ifaceCallHasCtx(call, fn, t, iface, at), true
+	}
+	fn, isFunc := typeutil.Callee(s.pass.TypesInfo, call).(*types.Func)
+	if !isFunc {
+		return false, false
+	}
+	f := s.ctxResultOf(fn)
+	if f == nil {
+		return false, false
+	}
+	if f.Always {
+		s.note(call.Pos(), "%s always returns a value with context", fn.Name())
+		return true, true
+	}
+	if s.summaryHolds(f, call, at) {
+		return true, true
+	}
+	s.note(call.Pos(), "%s returns a value with context only for an argument with context", fn.Name())
+	return false, true
+}
+
+// summaryHolds reports whether f says call returns a value with context:
+// always, or for one of its arguments with context.
+func (s *state) summaryHolds(f *ctxResultFact, call *ast.CallExpr, at token.Pos) bool {
+	if f.Always {
+		return true
+	}
+	for _, i := range f.Params {
+		if i >= len(call.Args) {
+			continue
+		}
+		arg := call.Args[i]
+		if tk := s.prof.kindOf(s.pass.TypesInfo.TypeOf(arg)); tk != trackNone && s.exprHasCtx(tk, arg, at) {
+			s.note(arg.Pos(), "the result keeps the context of the argument %s", types.ExprString(arg))
+			return true
+		}
+	}
+	return false
+}
+
+// exportResultFacts exports the package's result summaries, for analyzers
+// registering a summary fact.
+func (s *state) exportResultFacts() {
+	for fn, f := range s.resultFuncs {
+		if fact := s.resultFact(f); fact != nil {
+			s.pass.ExportObjectFact(fn, fact)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package allowpkg pins the -allow-funcs allowlist. TestAllowFuncs runs it
+// with `allowpkg.run,(*allowpkg.server).start,/\.(init|setup[A-Z]\w*)$/`:
+// listed functions (and closures inside them) are silent even with a context
+// in scope, everything else is reported as usual.
+package allowpkg
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+var bg = context.Background()
+
+func init() {
+	log.Info().Msg("init matched by regexp - must not trigger")
+}
+
+// run is listed by exact name.
+func run() {
+	ctx := context.Background()
+	_ = ctx
+	log.Info().Msg("allowlisted function - must not trigger")
+	func() {
+		log.Info().Msg("closure inside allowlisted function - must not trigger")
+	}()
+}
+
+// setupDatabase is matched by the regexp.
+func setupDatabase(ctx context.Context) {
+	log.Info().Msg("regexp-matched function - must not trigger")
+}
+
+// runner shares a prefix with run but is not listed: exact entries do not
+// match by prefix.
+func runner(ctx context.Context) {
+	log.Info().Msg("not allowlisted - must trigger") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+type server struct{}
+
+// start is listed by its method full name.
+func (s *server) start(ctx context.Context) {
+	log.Info().Msg("allowlisted method - must not trigger")
+}
+
+// stop is a method on the same type that is not listed.
+func (s *server) stop(ctx context.Context) {
+	log.Info().Msg("method not allowlisted - must trigger") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+// pkgLevel is outside any function: the package-level context is available
+// and no FuncDecl can allowlist it.
+var pkgLevel = func() bool {
+	log.Info().Msg("package-level initialiser - must trigger") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+	return true
+}()
@@ -0,0 +1,23 @@
+// Package categorypkg pins the diagnostic categories and category-scoped
+// nolint directives. TestCategories lists the expected category of each call
+// in source order.
+package categorypkg
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func handle(ctx context.Context) {
+	log.Info().Ctx(ctx).Msg("has context") // no category
+	log.Info().Msg("missing")              // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	log.Info().Ctx(nil).Msg("nil")         // want "zerolog event calls Ctx\\(\\) with a non-context argument before Msg\\(\\)"
+	log.Info().Msg("silenced")             //nolint:zerologctx/missing-ctx // suppressed missing-ctx
+	log.Info().Ctx(nil).Msg("x")           //nolint:zerologctx/nil-ctx   // suppressed nil-ctx
+	log.Info().Msg("other category")       //nolint:zerologctx/nil-ctx // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+func startup() {
+	log.Info().Msg("no context here") // no-ctx-available
+}
@@ -0,0 +1,24 @@
+// Package coveragepkg pins the classification of terminal calls recorded in
+// the analyzer's Result. TestResultCalls lists the expected status and
+// context source of each call in source order.
+package coveragepkg
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func handle(ctx context.Context) {
+	log.Info().Ctx(ctx).Msg("inline") // has-context, inline-ctx
+	l := log.With().Ctx(ctx).Logger()
+	l.Info().Msg("contextual logger") // has-context, contextual-logger
+	e := log.Info().Ctx(ctx)
+	e.Send()                   // has-context, tracked-event
+	log.Info().Msg("missing")  // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+	log.Info().Msg("silenced") //nolint:zerologctx   // suppressed
+}
+
+func startup() {
+	log.Info().Msg("no context here") // no-context-available
+}
@@ -0,0 +1,81 @@
+// Package ctxloggerpkg pins -ctx-loggers, run with
+// ctxloggerpkg/middleware.Handle as the trusted middleware.
+package ctxloggerpkg
+
+import (
+	"context"
+	"time"
+
+	"ctxloggerpkg/middleware"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func local(ctx context.Context) {
+	zerolog.Ctx(ctx).Info().Msg("nothing stored yet") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	ctx = log.With().Ctx(ctx).Logger().WithContext(ctx)
+	zerolog.Ctx(ctx).Info().Msg("stored with context")
+	log.Ctx(ctx).Info().Msg("log.Ctx too")
+	l := zerolog.Ctx(ctx)
+	l.Info().Msg("through a variable")
+
+	child, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	zerolog.Ctx(child).Info().Msg("derived contexts keep the logger")
+}
+
+func withoutCtx(ctx context.Context) {
+	ctx = log.Logger.WithContext(ctx)
+	zerolog.Ctx(ctx).Info().Msg("stored without context") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+func crossPackage(ctx context.Context) {
+	reqCtx := middleware.WithLogger(ctx)
+	zerolog.Ctx(reqCtx).Info().Msg("from a fact")
+	zerolog.Ctx(middleware.WithGlobal(ctx)).Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+func callbacks(ctx context.Context) {
+	middleware.Run(ctx, func(ctx context.Context) {
+		zerolog.Ctx(ctx).Info().Msg("callback of a middleware with a fact")
+	})
+	middleware.Run(ctx, handle)
+	middleware.Handle(func(ctx context.Context) {
+		zerolog.Ctx(ctx).Info().Msg("callback of a trusted middleware")
+	})
+	run(ctx, func(c context.Context) {
+		zerolog.Ctx(c).Info().Msg("callback of a middleware of this package")
+	})
+}
+
+// handle is passed to middleware.Run by name.
+func handle(ctx context.Context) {
+	zerolog.Ctx(ctx).Info().Msg("handler")
+}
+
+// run stores a request logger like middleware.Run; its summary is computed
+// within the package.
+func run(ctx context.Context, fn func(context.Context)) { // want run:"ctxLogger\\(callback 1\\)"
+	ctx = log.With().Ctx(ctx).Logger().WithContext(ctx)
+	fn(ctx)
+}
+
+func untrusted(ctx context.Context) {
+	middleware.Maybe(ctx, true, func(ctx context.Context) {
+		zerolog.Ctx(ctx).Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	})
+	ignore(func(ctx context.Context) {
+		zerolog.Ctx(ctx).Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	})
+}
+
+// ignore never calls fn.
+func ignore(fn func(context.Context)) {}
+
+func defaultLogger(ctx context.Context) {
+	l := log.With().Ctx(ctx).Logger()
+	zerolog.DefaultContextLogger = &l
+	zerolog.Ctx(context.Background()).Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
@@ -0,0 +1,37 @@
+// Package middleware stores request loggers in contexts. ctxloggerpkg relies
+// on the facts the analyzer exports for it.
+package middleware
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WithLogger returns ctx carrying a request logger with context.
+func WithLogger(ctx context.Context) context.Context {
+	l := log.With().Ctx(ctx).Str("component", "api").Logger()
+	return l.WithContext(ctx)
+}
+
+// WithGlobal returns ctx carrying the global logger, which has no context.
+func WithGlobal(ctx context.Context) context.Context {
+	return log.Logger.WithContext(ctx)
+}
+
+// Run calls fn with a context carrying a request logger.
+func Run(ctx context.Context, fn func(context.Context)) {
+	fn(WithLogger(ctx))
+}
+
+// Maybe calls fn with a request logger only sometimes.
+func Maybe(ctx context.Context, enabled bool, fn func(context.Context)) {
+	if enabled {
+		fn(WithLogger(ctx))
+		return
+	}
+	fn(ctx)
+}
+
+// Handle is opaque to the analyzer; the test lists it in -trusted-middleware.
+func Handle(fn func(context.Context)) {}
@@ -0,0 +1,13 @@
+// Package decoratoroffpkg pins the undecoratedctx analyzer without
+// -require-ctx-decorators: no context is reported.
+package decoratoroffpkg
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func undecorated() {
+	log.Info().Ctx(context.Background()).Msg("not checked")
+}
@@ -0,0 +1,86 @@
+// Package decoratorpkg pins the undecoratedctx analyzer, run with
+// decoratorpkg/requestid.With as the required decorator.
+package decoratorpkg
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"decoratorpkg/requestid"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/zap"
+)
+
+var background = context.Background()
+
+// parameter: the caller is responsible for decorating ctx.
+func parameter(ctx context.Context) {
+	log.Info().Ctx(ctx).Msg("ok")
+	l := log.With().Ctx(ctx).Logger()
+	l.Info().Msg("ok")
+}
+
+func decorated(id string) {
+	ctx := requestid.With(context.Background(), id)
+	log.Info().Ctx(ctx).Msg("ok")
+	ctx2, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	log.Info().Ctx(ctx2).Msg("derived with context.WithTimeout")
+	log.Info().Ctx(requestid.With(background, id)).Msg("inline")
+}
+
+func redecorated(ctx context.Context, id string) {
+	ctx = context.Background()
+	log.Info().Ctx(ctx).Msg("x") // want `context ctx passed to Ctx\(\) is not derived from a required context decorator \(decoratorpkg/requestid.With\) or a parameter - it may lack the values log correlation depends on`
+	ctx = requestid.With(ctx, id)
+	log.Info().Ctx(ctx).Msg("ok")
+}
+
+func undecorated() {
+	ctx := context.Background()
+	log.Info().Ctx(ctx).Msg("x")            // want `context ctx passed to Ctx\(\) is not derived`
+	log.Info().Ctx(background).Msg("x")     // want `context background passed to Ctx\(\) is not derived`
+	log.Info().Ctx(context.TODO()).Msg("x") // want `context context.TODO\(\) passed to Ctx\(\) is not derived`
+	log.Info().Ctx(ctx).Msg("x")            //nolint:undecoratedctx
+}
+
+type server struct{ ctx context.Context }
+
+func (s *server) field() {
+	log.Info().Ctx(s.ctx).Msg("x") // want `context s.ctx passed to Ctx\(\) is not derived`
+}
+
+func closure(id string) {
+	ctx := requestid.With(context.Background(), id)
+	func() {
+		log.Info().Ctx(ctx).Msg("captured")
+	}()
+	go func(c context.Context) {
+		log.Info().Ctx(c).Msg("closure parameter")
+	}(context.Background())
+}
+
+// otherLibraries: the contexts given to the family's other libraries are
+// checked too.
+func otherLibraries(id string, logger *zap.Logger) {
+	ctx := context.Background()
+	slog.InfoContext(ctx, "x")            // want `context ctx passed to InfoContext\(\) is not derived`
+	logger.Info("x", zap.Any("ctx", ctx)) // want `context ctx passed to Info\(\) is not derived`
+	ctx = requestid.With(ctx, id)
+	slog.InfoContext(ctx, "ok")
+	logger.Info("ok", zap.Any("ctx", ctx))
+}
+
+// computedFrom: a context only read to compute a value logged alongside the
+// attached one is not attached itself.
+func computedFrom(ctx context.Context, logger *zap.Logger) {
+	raw := context.Background()
+	logger.Info("ok", zap.Any("ctx", ctx), traceField(raw))
+	slog.InfoContext(ctx, "ok", "id", idOf(raw))
+}
+
+func traceField(ctx context.Context) zap.Field { return zap.String("trace", idOf(ctx)) }
+
+func idOf(ctx context.Context) string { return "" }
@@ -0,0 +1,12 @@
+// Package requestid stores a request ID in a context, the decorator that
+// decoratorpkg is checked against.
+package requestid
+
+import "context"
+
+type key struct{}
+
+// With returns a copy of ctx carrying id.
+func With(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
@@ -0,0 +1,36 @@
+// Package explainpkg pins the -explain reasoning. TestExplain checks the
+// steps recorded for each terminal call in source order.
+package explainpkg
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+func tracked(ctx context.Context) {
+	l := log.With().Ctx(ctx).Logger()
+	l.Info().Msg("tracked logger")
+}
+
+func lookup(ctx context.Context) {
+	log.Ctx(ctx).Info().Msg("logger lookup") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+func reassigned(parent context.Context) {
+	l := log.With().Ctx(parent).Logger()
+	l = log.Logger
+	l.Info().Msg("reassigned") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+type worker struct {
+	reqCtx context.Context
+}
+
+func (w *worker) run() {
+	log.Info().Msg("receiver field") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+func none() {
+	log.Info().Msg("nothing in scope")
+}
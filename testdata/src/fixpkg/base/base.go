@@ -0,0 +1,17 @@
+// Package base provides services embedded by fixpkg's receivers.
+package base
+
+import "context"
+
+// Service exposes its context as a field.
+type Service struct {
+	Ctx context.Context
+}
+
+// Opaque keeps its context unexported behind a method.
+type Opaque struct {
+	ctx context.Context
+}
+
+// Context returns the service's context.
+func (o *Opaque) Context() context.Context { return o.ctx }
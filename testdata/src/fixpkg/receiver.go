@@ -0,0 +1,118 @@
+package fixpkg
+
+import (
+	"context"
+
+	"fixpkg/base"
+
+	"github.com/rs/zerolog/log"
+)
+
+type service struct {
+	ctx context.Context
+}
+
+// handler gets its context from an embedded struct.
+type handler struct {
+	service
+}
+
+func (h *handler) promoted() {
+	log.Info().Msg("fix must insert h.service.ctx") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+// direct: a direct field wins over a promoted one.
+type direct struct {
+	service
+	ctx context.Context
+}
+
+func (d direct) shallowest() {
+	log.Info().Msg("fix must insert d.ctx") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+// nested reaches the context through named struct fields.
+type nested struct {
+	deps struct {
+		svc service
+	}
+	next *nested
+}
+
+func (n *nested) nestedField() {
+	log.Info().Msg("fix must insert n.deps.svc.ctx") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+// tooDeep hides its context beyond the search depth.
+type tooDeep struct {
+	a struct{ b struct{ c service } }
+}
+
+func (t *tooDeep) beyondDepth() {
+	log.Info().Msg("not reported")
+}
+
+// remote embeds a struct of another package with an exported field.
+type remote struct {
+	base.Service
+}
+
+func (r *remote) exportedField() {
+	log.Info().Msg("fix must insert r.Service.Ctx") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+// opaque can only reach the embedded context through a method.
+type opaque struct {
+	base.Opaque
+}
+
+func (o *opaque) method() {
+	log.Info().Msg("fix must insert o.Context()") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+// selfCtx's context method does not use itself as the candidate.
+type selfCtx struct{}
+
+func (s *selfCtx) Context() context.Context {
+	log.Info().Msg("not reported")
+	return context.Background()
+}
+
+func (s *selfCtx) other() {
+	log.Info().Msg("fix must insert s.Context()") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+// viaPointer only reaches a context through a pointer field, which may be
+// nil, so no context is available.
+type viaPointer struct {
+	svc *service
+}
+
+func (v *viaPointer) pointerField() {
+	log.Info().Msg("not reported")
+}
+
+// fallback prefers Context over other context-returning methods.
+type fallback struct{}
+
+func (fallback) Background() context.Context { return context.Background() }
+
+func (fallback) Context() context.Context { return context.Background() }
+
+func (f fallback) preferred() {
+	log.Info().Msg("fix must insert f.Context()") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
+
+// generic's context method does not use itself as the candidate either.
+type generic[T any] struct {
+	v T
+}
+
+func (g *generic[T]) Context() context.Context {
+	log.Info().Msg("not reported")
+	return context.Background()
+}
+
+func (g *generic[T]) other() {
+	log.Info().Msg("fix must insert g.Context()") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
@@ -0,0 +1,77 @@
+// Package logr is a stub implementation of github.com/go-logr/logr for testing
+package logr
+
+import "context"
+
+// LogSink is the logging backend behind a Logger
+type LogSink interface {
+	Info(level int, msg string, keysAndValues ...any)
+	Error(err error, msg string, keysAndValues ...any)
+}
+
+// Logger is the logr logging API; it is a value type
+type Logger struct {
+	sink  LogSink
+	level int
+}
+
+// New returns a Logger backed by sink
+func New(sink LogSink) Logger {
+	return Logger{sink: sink}
+}
+
+// Discard returns a Logger that discards all messages
+func Discard() Logger {
+	return Logger{}
+}
+
+// Info logs a non-error message
+func (l Logger) Info(msg string, keysAndValues ...any) {}
+
+// Error logs an error message
+func (l Logger) Error(err error, msg string, keysAndValues ...any) {}
+
+// V returns a Logger for the given verbosity level
+func (l Logger) V(level int) Logger {
+	return l
+}
+
+// WithValues returns a Logger with additional key/value pairs
+func (l Logger) WithValues(keysAndValues ...any) Logger {
+	return l
+}
+
+// WithName returns a Logger with an added name segment
+func (l Logger) WithName(name string) Logger {
+	return l
+}
+
+// WithCallDepth returns a Logger that skips additional stack frames
+func (l Logger) WithCallDepth(depth int) Logger {
+	return l
+}
+
+// Enabled reports whether the Logger is enabled
+func (l Logger) Enabled() bool {
+	return true
+}
+
+// GetSink returns the Logger's sink
+func (l Logger) GetSink() LogSink {
+	return l.sink
+}
+
+// FromContext returns the Logger stored in ctx, or an error
+func FromContext(ctx context.Context) (Logger, error) {
+	return Logger{}, nil
+}
+
+// FromContextOrDiscard returns the Logger stored in ctx, or a discarding one
+func FromContextOrDiscard(ctx context.Context) Logger {
+	return Logger{}
+}
+
+// NewContext returns a copy of ctx carrying logger
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	return ctx
+}
@@ -0,0 +1,29 @@
+// Package globalpkg pins the globalctxlogger analyzer: loggers with context
+// assigned to log.Logger or zerolog.DefaultContextLogger are reported.
+package globalpkg
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func install(ctx context.Context) {
+	log.Logger = log.With().Ctx(ctx).Logger() // want "logger with context assigned to the global log.Logger - its context would be attached to every entry logged through it, not only to this call's"
+
+	l := zerolog.New(os.Stdout).With().Ctx(ctx).Logger()
+	zerolog.DefaultContextLogger = &l // want "logger with context assigned to the global zerolog.DefaultContextLogger"
+
+	log.Logger = log.Output(os.Stderr)
+	plain := zerolog.New(os.Stdout)
+	zerolog.DefaultContextLogger = &plain
+
+	log.Info().Msg("terminal calls are zerologctx's")
+}
+
+func suppressed(ctx context.Context) {
+	log.Logger = log.With().Ctx(ctx).Logger() //nolint:globalctxlogger // test setup
+	log.Logger = log.With().Ctx(ctx).Logger() //nolint:globalctxlogger/global-ctx-logger
+}
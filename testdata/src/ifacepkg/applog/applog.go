@@ -0,0 +1,42 @@
+// Package applog declares logging interfaces backed by zerolog and their
+// implementations, whose result facts ifacepkg imports.
+package applog
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Logger is implemented by Request only.
+type Logger interface {
+	Info() *zerolog.Event
+}
+
+// Auditor is implemented by Request and Plain.
+type Auditor interface {
+	Warn() *zerolog.Event
+}
+
+// Emitter is implemented by Request, and by ifacepkg outside this package.
+type Emitter interface {
+	Error() *zerolog.Event
+}
+
+// Request logs with the request's context.
+type Request struct {
+	ctx  context.Context
+	base *zerolog.Logger
+}
+
+func (r Request) Info() *zerolog.Event { return r.base.Info().Ctx(r.ctx) }
+
+func (r Request) Warn() *zerolog.Event { return r.base.Warn().Ctx(r.ctx) }
+
+func (r Request) Error() *zerolog.Event { return r.base.Error().Ctx(r.ctx) }
+
+// Plain logs without context.
+type Plain struct{}
+
+func (Plain) Warn() *zerolog.Event { return log.Warn() }
@@ -0,0 +1,81 @@
+// Package ifacepkg pins calls through logging interfaces, resolved to the
+// implementations in the package and its dependencies.
+package ifacepkg
+
+import (
+	"context"
+
+	"ifacepkg/applog"
+
+	"github.com/rs/zerolog"
+)
+
+func every(ctx context.Context, l applog.Logger) {
+	l.Info().Msg("every implementation has context")
+	e := l.Info()
+	e.Str("k", "v").Msg("through a variable")
+}
+
+func some(ctx context.Context, a applog.Auditor) {
+	a.Warn().Str("k", "v").Msg("x") // want `zerolog event missing .Ctx\(ctx\) before Msg\(\) - context should be included for proper log correlation \(created by applog.Auditor.Warn, not every implementation of which is known to have context\)`
+	a.Warn().Ctx(ctx).Msg("context attached at the call site")
+}
+
+func constrained[L applog.Logger](ctx context.Context, l L) {
+	l.Info().Msg("type parameter constrained by the interface")
+}
+
+// tracer has a single implementation, in this package.
+type tracer interface {
+	Trace() *zerolog.Event
+}
+
+type ctxTracer struct {
+	ctx context.Context
+	l   *zerolog.Logger
+}
+
+func (t ctxTracer) Trace() *zerolog.Event { return t.l.Trace().Ctx(t.ctx) } // want Trace:"ctxResult\\(always\\)"
+
+func local(ctx context.Context, t tracer) {
+	t.Trace().Msg("local implementation has context")
+}
+
+// unimplemented has no implementation the analysis can see.
+type unimplemented interface {
+	Debug() *zerolog.Event
+}
+
+func unknown(ctx context.Context, u unimplemented) {
+	u.Debug().Msg("x") // want `\(created by unimplemented.Debug, not every implementation of which is known to have context\)`
+}
+
+// ctxEmitter implements the exported applog.Emitter outside its package,
+// so other packages may too and the interface is not trusted.
+type ctxEmitter struct {
+	ctx context.Context
+	l   *zerolog.Logger
+}
+
+func (e ctxEmitter) Error() *zerolog.Event { return e.l.Error().Ctx(e.ctx) } // want Error:"ctxResult\\(always\\)"
+
+func foreign(ctx context.Context, em applog.Emitter) {
+	em.Error().Msg("x") // want `\(created by applog.Emitter.Error, not every implementation of which is known to have context\)`
+}
+
+// warner is sealed, but applog.Plain, without context, implements it too:
+// `var w warner = applog.Plain{}` compiles.
+type warner interface {
+	Warn() *zerolog.Event
+}
+
+type ctxWarner struct {
+	ctx context.Context
+	l   *zerolog.Logger
+}
+
+func (w ctxWarner) Warn() *zerolog.Event { return w.l.Warn().Ctx(w.ctx) } // want Warn:"ctxResult\\(always\\)"
+
+func sealedElsewhere(ctx context.Context, w warner) {
+	w.Warn().Msg("x") // want `\(created by warner.Warn, not every implementation of which is known to have context\)`
+}
@@ -0,0 +1,43 @@
+// Package logrpkg pins the logrctx analyzer: loggers taken from the context
+// (and loggers derived from them) are accepted, others are reported when a
+// context is available.
+package logrpkg
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+)
+
+var global = logr.Discard()
+
+func fromContext(ctx context.Context) {
+	logr.FromContextOrDiscard(ctx).Info("inline lookup")
+	l := logr.FromContextOrDiscard(ctx).WithValues("user", "u1")
+	l.Info("derived logger")
+	l.V(1).WithName("db").Error(nil, "derived again")
+}
+
+func fromContextTuple(ctx context.Context) {
+	l, err := logr.FromContext(ctx)
+	if err != nil {
+		return
+	}
+	l.Info("tuple lookup")
+}
+
+func notFromContext(ctx context.Context, logger logr.Logger) {
+	global.Info("package logger")  // want "logr Info\\(\\) called on a logger that does not come from the context - use logr.FromContextOrDiscard\\(ctx\\) for proper log correlation"
+	logger.Error(nil, "parameter") // want "logr Error\\(\\) called on a logger that does not come from the context - use logr.FromContextOrDiscard\\(ctx\\) for proper log correlation"
+	l := logr.FromContextOrDiscard(ctx)
+	l = global
+	l.Info("reassigned") // want "logr Info\\(\\) called on a logger that does not come from the context - use logr.FromContextOrDiscard\\(ctx\\) for proper log correlation"
+}
+
+func noContext(logger logr.Logger) {
+	logger.Info("nothing to look up")
+}
+
+func suppressed(ctx context.Context) {
+	global.Info("silenced") //nolint:logrctx
+}
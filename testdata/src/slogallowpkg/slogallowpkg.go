@@ -0,0 +1,16 @@
+// Package slogallowpkg checks that slogctx honours -allow-funcs, which it
+// shares with zerologctx. TestSlogAnalyzer runs it with `slogallowpkg.run`.
+package slogallowpkg
+
+import (
+	"context"
+	"log/slog"
+)
+
+func run(ctx context.Context) {
+	slog.Info("allowlisted function - must not trigger")
+}
+
+func serve(ctx context.Context) {
+	slog.Info("not allowlisted - must trigger") // want "slog Info\\(\\) called without context - use InfoContext\\(ctx, ...\\) for proper log correlation"
+}
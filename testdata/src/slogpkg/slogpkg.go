@@ -0,0 +1,59 @@
+// Package slogpkg pins the slogctx analyzer: plain slog calls with a context
+// in scope are reported with a fix to the *Context variant, calls that pass a
+// context (or have none to pass) are not.
+package slogpkg
+
+import (
+	"context"
+	"log/slog"
+)
+
+func plain(ctx context.Context) {
+	slog.Info("request started", "path", "/") // want "slog Info\\(\\) called without context - use InfoContext\\(ctx, ...\\) for proper log correlation"
+	slog.Debug("details")                     // want "slog Debug\\(\\) called without context - use DebugContext\\(ctx, ...\\) for proper log correlation"
+}
+
+func method(reqCtx context.Context, logger *slog.Logger) {
+	logger.Warn("slow")                           // want "slog Warn\\(\\) called without context - use WarnContext\\(ctx, ...\\) for proper log correlation"
+	slog.Default().Error("failed", "err", "boom") // want "slog Error\\(\\) called without context - use ErrorContext\\(ctx, ...\\) for proper log correlation"
+}
+
+func withContext(ctx context.Context, logger *slog.Logger) {
+	slog.InfoContext(ctx, "fine")
+	logger.ErrorContext(ctx, "fine")
+	slog.Log(ctx, slog.LevelInfo, "fine")
+	logger.LogAttrs(ctx, slog.LevelWarn, "fine", slog.String("k", "v"))
+}
+
+func nilContext(ctx context.Context) {
+	slog.InfoContext(nil, "nil context") // want "slog InfoContext\\(\\) called with a non-context argument - pass a context.Context for proper log correlation"
+}
+
+func noContext(logger *slog.Logger) {
+	slog.Info("nothing to pass")
+	logger.Warn("nothing to pass")
+}
+
+type server struct {
+	ctx context.Context
+}
+
+func (s *server) handle() {
+	slog.Info("from receiver field") // want "slog Info\\(\\) called without context - use InfoContext\\(ctx, ...\\) for proper log correlation"
+}
+
+func suppressed(ctx context.Context) {
+	slog.Info("silenced") //nolint:slogctx
+	slog.Info("multi-line",
+		"k", "v") //nolint:slogctx // the directive may trail the last line
+	slog.Info("other linter's directive") //nolint:zerologctx // want "slog Info\\(\\) called without context - use InfoContext\\(ctx, ...\\) for proper log correlation"
+}
+
+// notSlog has methods named like slog's; only log/slog is checked.
+type notSlog struct{}
+
+func (notSlog) Info(msg string, args ...any) {}
+
+func other(ctx context.Context) {
+	notSlog{}.Info("not slog")
+}
@@ -0,0 +1,63 @@
+package testpkg
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// TestTypeAssertion tests loggers and Events asserted back from interface
+// variables.
+func TestTypeAssertion(ctx context.Context) {
+	var v any = log.With().Ctx(ctx).Logger()
+	if l, ok := v.(zerolog.Logger); ok {
+		l.Info().Msg("comma-ok assertion")
+	}
+	l := v.(zerolog.Logger)
+	l.Info().Msg("single-value assertion")
+
+	var e any = log.Info().Ctx(ctx)
+	e.(*zerolog.Event).Msg("inline assertion")
+	copied := any(e)
+	copied.(*zerolog.Event).Msg("copied interface")
+
+	var plain any = zerolog.New(os.Stdout)
+	if l, ok := plain.(zerolog.Logger); ok {
+		l.Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	}
+
+	var reassigned any = log.Info().Ctx(ctx)
+	reassigned = log.Info()
+	reassigned.(*zerolog.Event).Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+// TestTypeSwitch tests type switch case variables.
+func TestTypeSwitch(ctx context.Context) {
+	var v any = log.With().Ctx(ctx).Logger()
+	switch l := v.(type) {
+	case zerolog.Logger:
+		l.Info().Msg("case variable")
+	case *zerolog.Event, string:
+		l.(*zerolog.Event).Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	default:
+		d := l.(zerolog.Logger)
+		d.Info().Msg("default clause copies the interface")
+	}
+}
+
+// anyHolder keeps a logger in an interface-typed field.
+type anyHolder struct {
+	logger any
+}
+
+// TestAssertedField tests loggers stored in any fields and asserted back.
+func TestAssertedField(ctx context.Context) {
+	l := log.With().Ctx(ctx).Logger()
+	h := anyHolder{logger: &l}
+	h.logger.(*zerolog.Logger).Info().Msg("asserted field")
+	if hl, ok := h.logger.(*zerolog.Logger); ok {
+		hl.Info().Msg("comma-ok on a field")
+	}
+}
@@ -0,0 +1,90 @@
+package testpkg
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// TestChannelSends tests loggers and Events received from channels whose
+// every send has context.
+func TestChannelSends(ctx context.Context) {
+	loggers := make(chan zerolog.Logger, 1)
+	loggers <- log.With().Ctx(ctx).Logger()
+	l := <-loggers
+	l.Info().Msg("received")
+	if l2, ok := <-loggers; ok {
+		l2.Info().Msg("comma-ok receive")
+	}
+	for each := range loggers {
+		each.Info().Msg("ranged channel")
+	}
+
+	events := make(chan *zerolog.Event, 1)
+	events <- log.Info().Ctx(ctx)
+	(<-events).Msg("inline receive")
+	select {
+	case e := <-events:
+		e.Msg("select receive")
+	default:
+	}
+
+	mixed := make(chan *zerolog.Event, 2)
+	mixed <- log.Info().Ctx(ctx)
+	mixed <- log.Info()
+	(<-mixed).Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+// workerPool hands loggers to its workers over a channel field.
+type workerPool struct {
+	jobs chan *zerolog.Logger
+}
+
+func (p *workerPool) submit(ctx context.Context) {
+	l := log.With().Ctx(ctx).Logger()
+	p.jobs <- &l
+}
+
+func (p *workerPool) TestChannelField(ctx context.Context) {
+	for l := range p.jobs {
+		l.Info().Msg("worker")
+	}
+}
+
+// TestFuncVars tests calls of function variables whose every assigned
+// function returns a value with context.
+func TestFuncVars(ctx context.Context) {
+	newEvent := func() *zerolog.Event { return log.Info().Ctx(ctx) }
+	newEvent().Msg("function literal")
+
+	var named func(context.Context) *zerolog.Logger = getLoggerWithContext
+	named(ctx).Info().Msg("function value")
+
+	copied := newEvent
+	copied().Msg("copied function variable")
+
+	mixed := func() *zerolog.Event { return log.Info().Ctx(ctx) }
+	mixed = func() *zerolog.Event { return log.Info() }
+	mixed().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	var plain func() *zerolog.Logger = getLogger
+	plain().Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+// eventSource keeps a callback producing Events.
+type eventSource struct {
+	next func() *zerolog.Event
+}
+
+// TestFuncField tests function-typed fields set by literals.
+func TestFuncField(ctx context.Context) {
+	src := eventSource{next: func() *zerolog.Event { return log.Warn().Ctx(ctx) }}
+	src.next().Msg("function field")
+}
+
+// TestFuncParam tests that function parameters, with nothing assigned, are
+// not trusted.
+func TestFuncParam(ctx context.Context, cb func() *zerolog.Event) {
+	cb().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
@@ -0,0 +1,88 @@
+package testpkg
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// tenantRouter keeps a logger per tenant; every store has context.
+type tenantRouter struct {
+	loggers map[string]zerolog.Logger
+	ptrs    map[string]*zerolog.Logger
+}
+
+func newTenantRouter() *tenantRouter {
+	return &tenantRouter{
+		loggers: make(map[string]zerolog.Logger),
+		ptrs:    map[string]*zerolog.Logger{},
+	}
+}
+
+func (r *tenantRouter) register(ctx context.Context, tenant string) {
+	r.loggers[tenant] = log.With().Ctx(ctx).Str("tenant", tenant).Logger()
+	l := log.With().Ctx(ctx).Logger()
+	r.ptrs[tenant] = &l
+}
+
+// TestContainerMap tests loggers loaded from a map whose every store has
+// context, by index, comma-ok and range.
+func (r *tenantRouter) TestContainerMap(ctx context.Context, tenant string) {
+	l := r.loggers[tenant]
+	l.Info().Msg("indexed")
+	r.ptrs[tenant].Info().Msg("indexed inline")
+	if l2, ok := r.loggers[tenant]; ok {
+		l2.Info().Msg("comma-ok")
+	}
+	for _, each := range r.loggers {
+		each.Info().Msg("ranged")
+	}
+}
+
+// mixedRouter has one store without context, which clears the container.
+type mixedRouter struct {
+	loggers map[string]*zerolog.Logger
+}
+
+func (r *mixedRouter) register(ctx context.Context, tenant string) {
+	l := log.With().Ctx(ctx).Logger()
+	r.loggers[tenant] = &l
+	plain := zerolog.New(os.Stdout)
+	r.loggers["default"] = &plain
+}
+
+func (r *mixedRouter) TestContainerMixed(ctx context.Context, tenant string) {
+	r.loggers[tenant].Info().Msg("mixed") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	for _, each := range r.loggers {
+		each.Info().Msg("mixed, ranged") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	}
+}
+
+// TestContainerSlice tests slices filled by literals and append, and arrays.
+func TestContainerSlice(ctx context.Context) {
+	l := log.With().Ctx(ctx).Logger()
+	list := []*zerolog.Logger{&l}
+	list = append(list, &l)
+	for _, each := range list {
+		each.Info().Msg("ranged slice")
+	}
+	list[0].Info().Msg("indexed slice")
+
+	arr := [2]zerolog.Logger{l, log.With().Ctx(ctx).Logger()}
+	arr[1].Info().Msg("indexed array")
+
+	copied := append([]*zerolog.Logger(nil), list...)
+	copied[0].Info().Msg("spread copy")
+
+	var withPlain []*zerolog.Logger
+	plain := zerolog.New(os.Stdout)
+	withPlain = append(withPlain, &l, &plain)
+	withPlain[0].Info().Msg("one plain element") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	unknown := loggersFromSomewhere()
+	unknown[0].Info().Msg("unclassified store") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+func loggersFromSomewhere() []*zerolog.Logger { return nil }
@@ -0,0 +1,85 @@
+package testpkg
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type assignedSvc struct {
+	zerolog.Logger
+}
+
+// TestEmbeddedLoggerAssign tests promoted Logger methods on a struct whose
+// embedded Logger is assigned.
+func TestEmbeddedLoggerAssign(ctx context.Context) {
+	var svc assignedSvc
+	svc.Logger = zerolog.New(os.Stdout)
+	svc.Info().Msg("promoted method, no context") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	svc.Logger = log.With().Ctx(ctx).Logger()
+	svc.Info().Msg("promoted method on a logger with context")
+	svc.Logger.Info().Msg("explicit field")
+	ev := svc.Warn()
+	ev.Msg("event variable")
+	derived := svc.With().Str("k", "v").Logger()
+	derived.Info().Msg("derived from the promoted With")
+}
+
+type literalSvc struct {
+	zerolog.Logger
+	name string
+}
+
+type pointerSvc struct {
+	*zerolog.Logger
+}
+
+type noCtxSvc struct {
+	zerolog.Logger
+}
+
+// TestEmbeddedLoggerLiteral tests embedded Loggers set by composite
+// literals, keyed, positional and through a pointer.
+func TestEmbeddedLoggerLiteral(ctx context.Context) {
+	svc := literalSvc{log.With().Ctx(ctx).Logger(), "api"}
+	svc.Info().Msg("positional literal")
+
+	l := log.With().Ctx(ctx).Logger()
+	p := &pointerSvc{Logger: &l}
+	p.Info().Msg("embedded pointer")
+
+	n := noCtxSvc{Logger: zerolog.New(os.Stdout)}
+	n.Info().Msg("literal without context") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+type embeddedBase struct {
+	zerolog.Logger
+}
+
+type outerSvc struct {
+	embeddedBase
+}
+
+// TestEmbeddedLoggerNested tests methods promoted through two levels of
+// embedding.
+func TestEmbeddedLoggerNested(ctx context.Context) {
+	o := outerSvc{embeddedBase{Logger: log.With().Ctx(ctx).Logger()}}
+	o.Info().Msg("promoted through two levels")
+}
+
+type updatedEmbeddedSvc struct {
+	zerolog.Logger
+}
+
+// TestEmbeddedLoggerUpdate tests UpdateContext promoted from an embedded
+// Logger.
+func TestEmbeddedLoggerUpdate(ctx context.Context) {
+	var svc updatedEmbeddedSvc
+	svc.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	svc.Info().Msg("updated in place")
+}
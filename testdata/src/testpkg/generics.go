@@ -0,0 +1,79 @@
+package testpkg
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// withField is a generic wrapper; its result has context when l has.
+func withField[T any](l *zerolog.Logger, key string, v T) *zerolog.Logger { // want withField:"ctxResult\\(params 0\\)"
+	derived := l.With().Interface(key, v).Logger()
+	return &derived
+}
+
+// requestLogger always returns a logger with context.
+func requestLogger[T any](ctx context.Context, id T) zerolog.Logger { // want requestLogger:"ctxResult\\(always\\)"
+	return log.With().Ctx(ctx).Interface("id", id).Logger()
+}
+
+// pick returns its argument, a type parameter constrained to
+// *zerolog.Logger.
+func pick[L interface{ *zerolog.Logger }](l L) L { // want pick:"ctxResult\\(params 0\\)"
+	return l
+}
+
+// debugOf creates an Event on a type parameter.
+func debugOf[L interface { // want debugOf:"ctxResult\\(params 0\\)"
+	*zerolog.Logger
+	Debug() *zerolog.Event
+}](l L) *zerolog.Event {
+	return l.Debug()
+}
+
+// TestGenericWrappers tests results of instantiated generic functions.
+func TestGenericWrappers(ctx context.Context) {
+	l := log.With().Ctx(ctx).Logger()
+	plain := zerolog.New(os.Stdout)
+
+	withField(&l, "k", 1).Info().Msg("generic wrapper keeps the context")
+	withField[string](&l, "k", "v").Info().Msg("explicit instantiation")
+	withField(&plain, "k", 1).Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	r := requestLogger(ctx, 42)
+	r.Info().Msg("generic constructor")
+
+	pick(&l).Info().Msg("type parameter result")
+	pick(&plain).Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	debugOf(&l).Msg("event on a type parameter")
+	debugOf(&plain).Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+// logVia logs on a type parameter with a context in scope.
+func logVia[L interface {
+	*zerolog.Logger
+	Info() *zerolog.Event
+}](ctx context.Context, l L) {
+	l.Info().Msg("type parameter without context") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+// scoped is a generic struct holding a logger.
+type scoped[T any] struct {
+	logger zerolog.Logger
+	value  T
+}
+
+func newScoped[T any](ctx context.Context, v T) scoped[T] {
+	return scoped[T]{logger: log.With().Ctx(ctx).Logger(), value: v}
+}
+
+// TestGenericStruct tests fields of instantiated generic structs, recorded
+// on the declared field.
+func TestGenericStruct(ctx context.Context) {
+	s := newScoped(ctx, "v")
+	s.logger.Info().Msg("field of an instantiated generic struct")
+	var t scoped[int]
+	t.logger.Info().Msg("same field, another instantiation")
+}
@@ -0,0 +1,22 @@
+package testpkg
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// TestGlobalLoggerAssign tests loggers with context installed globally:
+// reported by globalctxlogger (see globalpkg), and never trusted.
+func TestGlobalLoggerAssign(ctx context.Context) {
+	log.Logger = log.With().Ctx(ctx).Logger()
+	log.Logger.Info().Msg("global loggers are not trusted") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+	copied := log.Logger
+	copied.Info().Msg("nor are their copies") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	l := zerolog.New(os.Stdout).With().Ctx(ctx).Logger()
+	zerolog.DefaultContextLogger = &l
+	zerolog.DefaultContextLogger.Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
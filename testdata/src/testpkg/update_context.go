@@ -0,0 +1,101 @@
+package testpkg
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// TestUpdateContextLocal tests a logger variable updated in place.
+func TestUpdateContextLocal(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.Info().Msg("before the update") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	l.Info().Msg("after the update")
+	warn := l.Level(zerolog.WarnLevel)
+	warn.Warn().Msg("derived after the update")
+}
+
+// TestUpdateContextIntermediate tests closures returning the builder through
+// intermediate variables.
+func TestUpdateContextIntermediate(ctx context.Context) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		withCtx := c.Ctx(ctx)
+		b := withCtx.Str("component", "api")
+		return b
+	})
+	l.Info().Msg("ok")
+
+	l2 := zerolog.New(os.Stdout)
+	l2.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		c = c.Str("component", "api")
+		c = c.Ctx(ctx)
+		return c
+	})
+	l2.Info().Msg("ok")
+}
+
+// TestUpdateContextWithoutCtx tests updates that do not attach a context on
+// every path.
+func TestUpdateContextWithoutCtx(ctx context.Context, verbose bool) {
+	l := zerolog.New(os.Stdout)
+	l.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Str("component", "api")
+	})
+	l.Info().Msg("fields only") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	l2 := zerolog.New(os.Stdout)
+	l2.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		if verbose {
+			return c.Str("mode", "verbose")
+		}
+		return c.Ctx(ctx)
+	})
+	l2.Info().Msg("one path only") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	// Only function literals are followed.
+	update := func(c zerolog.Context) zerolog.Context { return c.Ctx(ctx) }
+	l3 := zerolog.New(os.Stdout)
+	l3.UpdateContext(update)
+	l3.Info().Msg("named function") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+
+	// Returns of nested function literals do not count.
+	l4 := zerolog.New(os.Stdout)
+	l4.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		attach := func(b zerolog.Context) zerolog.Context { return b.Ctx(ctx) }
+		_ = attach
+		return c
+	})
+	l4.Info().Msg("nested literal") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\)"
+}
+
+type updatedService struct {
+	logger zerolog.Logger
+}
+
+// TestUpdateContextField tests a logger field updated in place.
+func (s *updatedService) TestUpdateContextField(ctx context.Context) {
+	s.logger.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	s.logger.Info().Msg("ok")
+}
+
+// TestUpdateContextPointer tests a logger updated through a pointer.
+func TestUpdateContextPointer(ctx context.Context, p *zerolog.Logger, q *zerolog.Logger) {
+	p.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	p.Info().Msg("ok")
+
+	(*q).UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Ctx(ctx)
+	})
+	q.Info().Msg("ok")
+	(*q).Info().Msg("ok")
+}
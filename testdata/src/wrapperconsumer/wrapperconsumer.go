@@ -14,3 +14,12 @@ func correct() {
 func incorrect(ctx context.Context) {
 	wrappkg.Info().Msg("missing context through wrapper") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
 }
+
+func helpers(ctx context.Context) {
+	l := wrappkg.FromCtx(ctx)
+	l.Info().Msg("logger returned by a helper of another package")
+	wrappkg.With(l, "k", 1).Info().Msg("generic wrapper keeps the context")
+	wrappkg.With(wrappkg.NewLogger(), "k", "v").Info().Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+	wrappkg.InfoOf(l).Msg("type parameter constrained to *zerolog.Logger")
+	wrappkg.InfoOf(wrappkg.NewLogger()).Msg("x") // want "zerolog event missing .Ctx\\(ctx\\) before Msg\\(\\) - context should be included for proper log correlation"
+}
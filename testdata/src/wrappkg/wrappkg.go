@@ -1,15 +1,40 @@
 package wrappkg
 
 import (
+	"context"
 	"os"
 
 	"github.com/rs/zerolog"
 )
 
-func NewLogger() zerolog.Logger {
-	return zerolog.New(os.Stdout)
+func NewLogger() *zerolog.Logger {
+	l := zerolog.New(os.Stdout)
+	return &l
 }
 
 func Info() *zerolog.Event {
 	return NewLogger().Info()
 }
+
+// FromCtx returns a logger carrying ctx; its result fact is always.
+func FromCtx(ctx context.Context) *zerolog.Logger {
+	l := zerolog.New(os.Stdout).With().Ctx(ctx).Logger()
+	return &l
+}
+
+// With is a generic wrapper keeping the context of l.
+func With[T any](l *zerolog.Logger, key string, v T) *zerolog.Logger {
+	derived := l.With().Interface(key, v).Logger()
+	return &derived
+}
+
+// LoggerPtr is satisfied by *zerolog.Logger only.
+type LoggerPtr interface {
+	*zerolog.Logger
+	Info() *zerolog.Event
+}
+
+// InfoOf creates an Event on a logger whose type is a type parameter.
+func InfoOf[L LoggerPtr](l L) *zerolog.Event {
+	return l.Info()
+}
@@ -0,0 +1,54 @@
+// Package zappkg pins the zapctx analyzer: calls carrying a field built from
+// the context, directly or through With(...), are accepted; others are
+// reported when a context is available.
+package zappkg
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ctxField is a project's own context-field helper, listed by -ctx-fields.
+func ctxField(ctx context.Context) zap.Field {
+	return zap.Any("trace", ctx)
+}
+
+func withField(ctx context.Context, logger *zap.Logger) {
+	logger.Info("inline field", zap.Any("ctx", ctx))
+	logger.Debug("reflected field", zap.Reflect("ctx", ctx))
+	logger.Warn("helper field", ctxField(ctx))
+	l := logger.With(ctxField(ctx)).Named("db")
+	l.Error("derived logger")
+	l.Sugar().Infow("sugared from derived")
+	zap.S().Infow("sugared pair", "ctx", ctx)
+}
+
+func withoutField(ctx context.Context, logger *zap.Logger) {
+	logger.Info("no field", zap.String("k", "v"))   // want "zap Info\\(\\) called without a context field - pass a field built from ctx \\(or log through a logger from With\\(...\\) carrying one\\) for proper log correlation"
+	zap.L().Debug("global")                         // want "zap Debug\\(\\) called without a context field"
+	zap.S().Infof("sugared %d", 1)                  // want "zap Infof\\(\\) called without a context field"
+	logger.With(zap.String("k", "v")).Error("x")    // want "zap Error\\(\\) called without a context field"
+	logger.Error("failed", zap.Error(doWork(ctx)))  // want "zap Error\\(\\) called without a context field"
+	logger.Info("unlisted helper", otherField(ctx)) // want "zap Info\\(\\) called without a context field"
+	_ = logger.Sync()
+}
+
+// doWork takes the context, but the field built from its result does not
+// carry it.
+func doWork(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// otherField takes the context but is not listed by -ctx-fields.
+func otherField(ctx context.Context) zap.Field {
+	return zap.String("deadline", "none")
+}
+
+func noContext(logger *zap.Logger) {
+	logger.Info("nothing to pass")
+}
+
+func suppressed(ctx context.Context, logger *zap.Logger) {
+	logger.Info("silenced") //nolint:zapctx
+}
@@ -0,0 +1,125 @@
+package zerologctx
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// zapPkgPath is the import path of the zap logging library.
+const zapPkgPath = "go.uber.org/zap"
+
+// ZapAnalyzer is the zapctx analyzer: Analyzer's rule for go.uber.org/zap,
+// which has no context API of its own; the convention is a context field
+// built by a helper that takes the context (zap.Any("ctx", ctx), or a
+// project's own logging.CtxField(ctx)).
+var ZapAnalyzer = newProfileAnalyzer("zapctx", `Ensures zap log calls carry a context field when a context is available.
+This analyzer reports logging calls on *zap.Logger and *zap.SugaredLogger
+(Info, Errorw, Debugf, ...) that pass no field built from a context.Context
+— neither directly nor through a logger derived with With(...) — when a
+context.Context is available at the call site (a function parameter, a local
+variable declared before the call, a package-level variable, or a
+context-typed field of the method's receiver). A field counts when it is the
+context itself, zap.Any or zap.Reflect given the context, or a call of one of
+the helpers listed by -ctx-fields given it. Calls inside the functions listed
+by -allow-funcs are not reported.`, zapProfile{ctxFields: &zapSettings.ctxFields}, &zapSettings)
+
+// zapProfile describes zap: *zap.Logger and *zap.SugaredLogger are tracked
+// loggers, the level methods emit, and With(...) or a terminal call attaches
+// the context when one of its arguments carries it. ctxFields is the running
+// analyzer's -ctx-fields list.
+type zapProfile struct {
+	ctxFields *funcPatterns
+}
+
+// zapLevels are the level method names; the SugaredLogger adds the f, w and
+// ln variants.
+var zapLevels = []string{"Debug", "Info", "Warn", "Error", "DPanic", "Panic", "Fatal", "Log"}
+
+func (zapProfile) pkgPath() string      { return zapPkgPath }
+func (zapProfile) importsContext() bool { return false }
+
+func (zapProfile) kindOf(t types.Type) trackKind {
+	if isNamed(t, zapPkgPath, "Logger") || isNamed(t, zapPkgPath, "SugaredLogger") {
+		return trackLogger
+	}
+	return trackNone
+}
+
+func (zapProfile) isTerminal(k trackKind, name string) bool {
+	if k != trackLogger {
+		return false
+	}
+	for _, level := range zapLevels {
+		switch name {
+		case level, level + "f", level + "w", level + "ln":
+			return true
+		}
+	}
+	return false
+}
+
+// attaches reports whether a With(...) or terminal call passes an argument
+// carrying the context: a context itself (SugaredLogger key/value pairs) or a
+// field holding it — zap.Any or zap.Reflect given the context, or a
+// -ctx-fields helper given it. Other calls taking the context, such as
+// zap.Error(doWork(ctx)), build their field from what they compute, not from
+// the context.
+func (p zapProfile) attaches(call *ast.CallExpr, fn *types.Func, recv trackKind, isCtx func(ast.Expr) bool, callee func(*ast.CallExpr) *types.Func) attachResult {
+	if recv != trackLogger || (fn.Name() != "With" && !p.isTerminal(recv, fn.Name())) {
+		return attachNone
+	}
+	for _, arg := range call.Args {
+		if isCtx(arg) {
+			return attachCtx
+		}
+		if c, ok := ast.Unparen(arg).(*ast.CallExpr); ok && p.isCtxField(callee(c)) && slices.ContainsFunc(c.Args, isCtx) {
+			return attachCtx
+		}
+	}
+	return attachNone
+}
+
+// isCtxField reports whether fn builds a field holding the value it is
+// given: zap.Any, zap.Reflect, or a helper listed by -ctx-fields.
+func (p zapProfile) isCtxField(fn *types.Func) bool {
+	if fn == nil {
+		return false
+	}
+	if fn.Pkg() != nil && fn.Pkg().Path() == zapPkgPath && (fn.Name() == "Any" || fn.Name() == "Reflect") {
+		return true
+	}
+	return p.ctxFields.matches(fn)
+}
+
+// storesLogger and loadsLogger return nil: zap has no context-stored
+// loggers.
+func (zapProfile) storesLogger(*ast.CallExpr, *types.Func, trackKind) ast.Expr { return nil }
+func (zapProfile) loadsLogger(*ast.CallExpr, *types.Func, trackKind) ast.Expr  { return nil }
+
+// updater returns nil: zap loggers are immutable.
+func (zapProfile) updater(*ast.CallExpr, *types.Func, trackKind) ast.Expr { return nil }
+
+// globalLogger returns "": zap's global loggers are replaced by
+// zap.ReplaceGlobals, not by assignment.
+func (zapProfile) globalLogger(*types.Var) string { return "" }
+
+func (zapProfile) missingCtx(call *ast.CallExpr, sel *ast.SelectorExpr, ctxName string) analysis.Diagnostic {
+	return analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf(
+			"zap %s() called without a context field - pass a field built from ctx (or log through a logger from With(...) carrying one) for proper log correlation",
+			sel.Sel.Name,
+		),
+	}
+}
+
+// nonCtxArg is unreachable: zap has no dedicated context parameter, so an
+// argument that is not a context is just another field.
+func (p zapProfile) nonCtxArg(call *ast.CallExpr, sel *ast.SelectorExpr) analysis.Diagnostic {
+	return p.missingCtx(call, sel, "")
+}
@@ -12,16 +12,80 @@
 //   - Context propagated through assignments and aliases of Event, Logger and
 //     zerolog.Context (builder) variables:
 //     e := log.Info().Ctx(ctx); e.Msg("hi")
+//   - Struct fields set by assignments or composite literals, including
+//     embedded Loggers whose methods are promoted:
+//     svc := Svc{Logger: ctxLogger}; svc.Info().Msg("hi")
+//   - Maps, slices, arrays and channels of loggers (or Events, or builders)
+//     whose every element store or send has context, read by index, receive
+//     or range:
+//     loggers[k] = ctxLogger; l := loggers[k]; l.Info().Msg("hi")
+//   - Function-typed variables and fields whose every assigned function
+//     returns a value with context:
+//     next := func() *zerolog.Event { return log.Info().Ctx(ctx) }; next().Msg("hi")
+//   - Interface-typed variables and fields holding one, asserted back by a
+//     type assertion (plain or comma-ok) or a type switch:
+//     var v any = ctxLogger; if l, ok := v.(zerolog.Logger); ok { ... }
 //   - A mutating statement on a tracked Event variable (zerolog Event methods
 //     mutate the receiver in place):
 //     e := log.Info(); e.Ctx(ctx); e.Msg("hi")
+//   - Logger.UpdateContext with a function literal returning a builder with
+//     context, which updates the logger variable, field or pointer target in
+//     place:
+//     l.UpdateContext(func(c zerolog.Context) zerolog.Context { return c.Ctx(ctx) })
+//   - Loggers, Events and builders returned by functions whose summary says
+//     every return has context, or keeps the context of an argument that has
+//     it; generic functions are summarised once for every instantiation, and
+//     type parameters constrained to zerolog types are tracked as such:
+//     withUser(&ctxLogger, id).Info().Msg("hi")
+//   - Calls through interface methods (`type Logger interface { Info()
+//     *zerolog.Event }`) every implementation of which, in the package and
+//     its dependencies, has such a summary; otherwise the diagnostic names
+//     the interface method.
 //   - Custom context types satisfying context.Context (e.g. via embedding).
 //
 // A diagnostic is emitted only when a context is actually available at the
 // call site — a context.Context-typed function parameter, a local variable
-// declared before the call, a package-level variable, or a field of the
-// enclosing method's receiver. Calls in code that has no context to pass are
-// not reported.
+// declared before the call, a package-level variable, or a field (possibly
+// promoted or nested) or context-returning method of the enclosing method's
+// receiver. Calls in code that has no context to pass are not reported.
+//
+// The -allow-funcs flag lists functions inside which calls are never
+// reported, for startup code that intentionally logs without the context it
+// has in scope. Entries are fully-qualified names as printed by
+// types.Func.FullName (example.com/cmd/app.run, (*example.com/pkg.Server).Start)
+// or /regexp/ patterns matched against them (/\.(main|init|TestMain)$/). The
+// enclosing top-level declaration decides, so closures inside a listed
+// function are covered too.
+//
+// With -explain, each diagnostic carries the reasoning behind it as related
+// information (the assignment whose fact decided, Logger lookups that do not
+// attach context, the context candidate chosen for the fix), and every
+// classified call in the Result records the same steps.
+//
+// The library-specific parts — which types are tracked, which calls emit an
+// entry and which attach a context — are described by a libraryProfile.
+// LogrAnalyzer and ZapAnalyzer run the same engine with profiles for
+// github.com/go-logr/logr and go.uber.org/zap.
+//
+// UndecoratedCtxAnalyzer (undecoratedctx) is opt-in: its
+// -require-ctx-decorators flag lists functions that decorate a context with
+// the values correlation depends on (a request ID); contexts attached to log
+// entries must then be traceable, within their function, to one of them or
+// to a parameter. GlobalCtxLoggerAnalyzer (globalctxlogger) reports loggers
+// with context installed as log.Logger or zerolog.DefaultContextLogger. Each
+// has its own flags and its own nolint identifier.
+//
+// The opt-in -ctx-loggers flag makes the logger loaded by zerolog.Ctx(ctx) or
+// log.Ctx(ctx) count as having context when ctx provably carries a logger
+// with context: stored by Logger.WithContext in the function, returned by a
+// function known to do so (across packages, through an analysis.Fact), or
+// received by a function passed to such a middleware or to one listed by
+// -trusted-middleware.
+//
+// Every diagnostic carries a stable Category: CategoryMissingCtx for a call
+// that could pass an available context, CategoryNilCtx for Ctx(nil),
+// CategoryUndecoratedCtx for undecoratedctx findings,
+// CategoryGlobalCtxLogger for globalctxlogger findings.
 //
 // A //nolint:zerologctx (or //nolint:all, or bare //nolint) comment is
 // honoured when it appears on one of the chain's own lines (from the chain
@@ -31,21 +95,46 @@
 //
 // # Known limitations
 //
-// The analysis is flow-insensitive and intra-package by design:
+// The analysis is flow-insensitive. Variables, fields and containers are
+// tracked within a package; across packages, functions are followed through
+// the analysis.Facts exported for them: their result summaries and, with
+// -ctx-loggers, whether the contexts they return carry a logger with
+// context.
 //
 //   - An assignment inside a conditional branch is treated as unconditional:
 //     after `if cond { l = ctxLogger }` the analyzer assumes l has context.
 //   - Struct fields are tracked per field declaration, not per instance:
-//     `a.logger = ctxLogger` also marks `b.logger` for other values of the
-//     same struct type.
-//   - Composite-literal initialisation (`App{logger: ctxLogger}`) is not
-//     tracked.
-//   - Facts do not cross package boundaries (no analysis.Facts): an exported
-//     context-bearing logger declared in another package is not recognised.
+//     `a.logger = ctxLogger` (or `App{logger: ctxLogger}`) also marks
+//     `b.logger` for other values of the same struct type.
+//   - Only functions carry facts across packages: an exported package-level
+//     logger with context declared in another package is not recognised,
+//     nor is a struct field set there. The library's global loggers
+//     (log.Logger, zerolog.DefaultContextLogger) are never trusted:
+//     installing a logger with context in one is reported by
+//     globalctxlogger instead.
+//   - Container and function-variable facts ignore the order of stores, and
+//     stores made outside the variable's own assignments and sends (through
+//     a pointer, an alias, or by a function it is passed to) are not seen. A
+//     channel or callback received as a parameter has no stores, so values
+//     received from it or returned by it are not trusted.
 //   - Method values (`m := e.Msg; m("...")`) are not checked.
-//   - Loggers and Events returned by helper functions, and loggers received
-//     as function parameters, are not recognised; attach the context to the
-//     Event at the call site instead.
+//   - Loggers received as function parameters are not recognised within
+//     the function, only through its result summary; attach the context to
+//     the Event at the call site instead. Function values have no summary.
+//   - Implementations of an interface are only searched for among the
+//     package's types and the package-level types of its dependencies (for
+//     an exported interface, of the interface's package and the
+//     dependencies importing it), and must declare the method: one promoted
+//     from an embedded zerolog.Logger counts as one without context. Types
+//     declared inside the dependencies' functions are not seen, nor are
+//     zerolog's own types, so a *zerolog.Logger stored in the interface
+//     directly is not seen.
+//   - Packages that are not dependencies can implement an exported
+//     interface too, so one is only trusted when every implementation found
+//     is in its own package; calls through an exported interface
+//     implemented elsewhere, or through an interface literal, are reported.
+//     Unexported interfaces are trusted on the implementations found in the
+//     package and all its dependencies.
 //   - Only the canonical github.com/rs/zerolog import path is recognised;
 //     forks and copies vendored under other paths are not.
 package zerologctx
@@ -55,11 +144,13 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"reflect"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
 )
 
 // zerologPkgPath is the canonical import path of the zerolog library used
@@ -76,20 +167,12 @@ Msg(), Msgf(), MsgFunc() or Send() without calling Ctx(ctx) first in the
 chain — but only when a context.Context is actually available at the call
 site: as a function parameter, a local variable declared before the call, a
 package-level variable, or a context-typed field of the method's receiver.
-Calls with no reachable context are not reported.`,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
-	Run:      run,
-}
-
-// terminalMethods are the *zerolog.Event methods that produce output and must
-// be preceded by Ctx() somewhere in the chain. Keep in sync with zerolog's
-// Event terminals; non-terminal methods (Str, Int, Dict, Discard, ...) must
-// not appear here.
-var terminalMethods = map[string]struct{}{
-	"Msg":     {}, // log.Info().Msg("message")
-	"Msgf":    {}, // log.Info().Msgf("message %d", 42)
-	"MsgFunc": {}, // log.Info().MsgFunc(func() string { return "message" })
-	"Send":    {}, // log.Info().Send()
+Calls with no reachable context are not reported, nor are calls inside the
+functions listed by -allow-funcs.`,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	Run:        run,
+	ResultType: reflect.TypeFor[*Result](),
+	FactTypes:  []analysis.Fact{new(ctxLoggerFact), new(ctxResultFact)},
 }
 
 // factKind describes what the analyzer knows about a tracked variable at a
@@ -107,8 +190,22 @@ const (
 	factEventCtx
 )
 
-// trackKindOf classifies a type as one of the zerolog value kinds the
-// analyzer records facts for, or trackNone for everything else.
+// String describes the fact for -explain output.
+func (k factKind) String() string {
+	switch k {
+	case factLoggerCtx:
+		return "a logger with context"
+	case factBuilderCtx:
+		return "a builder with Ctx(ctx) applied"
+	case factEventCtx:
+		return "an Event with context"
+	}
+	return "a value without context"
+}
+
+// trackKind classifies a type as one of the logging library's value kinds
+// the analyzer records facts for (see libraryProfile.kindOf), or trackNone
+// for everything else.
 type trackKind uint8
 
 const (
@@ -118,18 +215,6 @@ const (
 	trackBuilder
 )
 
-func trackKindOf(t types.Type) trackKind {
-	switch {
-	case isZerologLogger(t):
-		return trackLogger
-	case isZerologEvent(t):
-		return trackEvent
-	case isZerologContext(t):
-		return trackBuilder
-	}
-	return trackNone
-}
-
 // positiveFactFor maps a track category to the positive fact kind a variable
 // of that category may carry. The two enums stay in one-to-one correspondence
 // through this function, and factTable.set enforces it.
@@ -157,6 +242,14 @@ const maxFactPasses = 10
 type state struct {
 	pass *analysis.Pass
 
+	// prof describes the logging library being checked. Nil for analyzers
+	// that only use the shared context search and suppression code
+	// (SlogAnalyzer).
+	prof libraryProfile
+
+	// cfg holds the running analyzer's flag values.
+	cfg *settings
+
 	// contextIface is the canonical context.Context interface, found by
 	// scanImports. Non-nil whenever run() proceeds past its early exit.
 	contextIface *types.Interface
@@ -185,10 +278,48 @@ type state struct {
 	// documented nolint semantics.
 	readErr error
 
+	// result accumulates what the pass returns to drivers alongside its
+	// diagnostics.
+	result *Result
+
 	// noInitVars caches the set of variables declared without an initializer
 	// (`var c context.Context`); such variables make poor suggested-fix
 	// candidates. Built lazily by noInitVarSet.
 	noInitVars map[types.Object]bool
+
+	// ctxAssigns and params index context assignments and parameters for
+	// the -require-ctx-decorators check. Built lazily.
+	ctxAssigns map[types.Object][]ctxAssignment
+	params     map[types.Object]bool
+
+	// containers records the maps, slices and arrays whose elements have
+	// context (see containers.go), derived from the elemStores of the last
+	// fact-collection pass; elemStores collects the current pass's.
+	containers map[types.Object]bool
+	elemStores map[types.Object]*elemStores
+
+	// funcDecls indexes the package's function declarations (see
+	// funcDeclMap); resultFuncs holds the summaries of those returning a
+	// tracked value (see summaries.go).
+	funcDecls   map[*types.Func]*ast.FuncDecl
+	resultFuncs map[*types.Func]*ctxResultFact
+
+	// typeNames and ifaceImpls cache the types implementations of interface
+	// methods are looked up in, per search scope, and the
+	// implementations found (see ifaces.go).
+	typeNames  map[typeScope]typeSet
+	ifaceImpls map[ifaceMethod][]*types.Func
+
+	// ctxLoggerFuncs and trustedParams hold the -ctx-loggers state: the
+	// package's function summaries and the parameters receiving a context
+	// that carries a logger with context. Built by updateCtxLoggers.
+	ctxLoggerFuncs map[*types.Func]*ctxLoggerFact
+	trustedParams  map[types.Object]bool
+
+	// tracing is set while handleCall classifies a call under -explain;
+	// note then appends the predicates' reasoning to trace.
+	tracing bool
+	trace   []analysis.RelatedInformation
 }
 
 // newState constructs a fresh analysis state for the given pass, including
@@ -196,11 +327,23 @@ type state struct {
 // token.File surfaces FileSet corruption immediately rather than silently
 // skipping files later, which would cause //nolint:zerologctx directives to
 // be unexpectedly ignored.
-func newState(pass *analysis.Pass, contextIface *types.Interface) (*state, error) {
+func newState(pass *analysis.Pass, prof libraryProfile, cfg *settings, contextIface *types.Interface) (*state, error) {
+	kindOf := func(types.Type) trackKind { return trackNone }
+	if prof != nil {
+		kindOf = prof.kindOf
+	}
 	s := &state{
 		pass:         pass,
+		prof:         prof,
+		cfg:          cfg,
 		contextIface: contextIface,
-		facts:        newFactTable(),
+		facts:        newFactTable(kindOf),
+		containers:   make(map[types.Object]bool),
+		elemStores:   make(map[types.Object]*elemStores),
+		resultFuncs:  make(map[*types.Func]*ctxResultFact),
+		typeNames:    make(map[typeScope]typeSet),
+		ifaceImpls:   make(map[ifaceMethod][]*types.Func),
+		result:       &Result{},
 		fileMap:      make(map[*token.File]*ast.File, len(pass.Files)),
 		commentIndex: make(map[*ast.File]map[int][]*ast.Comment),
 		srcCache:     make(map[*token.File][]byte),
@@ -208,7 +351,7 @@ func newState(pass *analysis.Pass, contextIface *types.Interface) (*state, error
 	for _, f := range pass.Files {
 		pf := pass.Fset.File(f.Pos())
 		if pf == nil {
-			return nil, fmt.Errorf("zerologctx: FileSet.File returned nil for %s; this indicates a corrupted FileSet", f.Name)
+			return nil, fmt.Errorf("%s: FileSet.File returned nil for %s; this indicates a corrupted FileSet", pass.Analyzer.Name, f.Name)
 		}
 		s.fileMap[pf] = f
 	}
@@ -217,26 +360,55 @@ func newState(pass *analysis.Pass, contextIface *types.Interface) (*state, error
 
 // run is the analyzer entry point.
 func run(pass *analysis.Pass) (any, error) {
+	return runProfile(pass, zerologProfile{}, &zerologSettings, checkCalls)
+}
+
+// check selects what a run of the shared engine reports. Each check is its
+// own analyzer, with its own name, enable flag and //nolint identifier.
+type check uint8
+
+const (
+	// checkCalls reports terminal calls without context (zerologctx,
+	// logrctx, zapctx).
+	checkCalls check = iota
+	// checkGlobals reports loggers with context installed as the library's
+	// global loggers (globalctxlogger).
+	checkGlobals
+)
+
+// runProfile runs the shared engine for the logging library described by
+// prof, with the running analyzer's settings cfg, and reports what chk
+// selects. Errors are prefixed with the running analyzer's name.
+func runProfile(pass *analysis.Pass, prof libraryProfile, cfg *settings, chk check) (any, error) {
+	name := pass.Analyzer.Name
 	insp, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
-		return nil, fmt.Errorf("zerologctx: inspect.Analyzer result missing or wrong type")
+		return nil, fmt.Errorf("%s: inspect.Analyzer result missing or wrong type", name)
 	}
 
-	// Packages without zerolog in their transitive import graph have nothing
-	// to analyse — the common case in monorepos, and a silent skip by design.
-	hasZerolog, contextIface := scanImports(pass.Pkg)
-	if !hasZerolog {
-		return nil, nil
+	// Packages without the library in their transitive import graph have
+	// nothing to analyse — the common case in monorepos, and a silent skip
+	// by design.
+	hasLib, contextIface := scanImports(pass.Pkg, prof.pkgPath())
+	if !hasLib {
+		return &Result{}, nil
 	}
-	// zerolog itself imports "context", so with zerolog present the interface
+	// The library's own packages are not checked. Analyzers with facts also
+	// run on dependencies, which include the library itself.
+	if inPackage(pass.Pkg.Path(), prof.pkgPath()) {
+		return &Result{}, nil
+	}
+	// zerolog imports "context" itself, so with it present the interface
 	// must be discoverable. Failing to find it means the driver served an
 	// incomplete import graph; skipping silently here would disable the
-	// linter for a package that actively uses zerolog.
-	if contextIface == nil {
-		return nil, fmt.Errorf("zerologctx: could not locate context.Context in the import graph of %s", pass.Pkg.Path())
+	// linter for a package that actively logs. For libraries that do not
+	// import "context", a nil interface just means no context is available
+	// anywhere in the package.
+	if contextIface == nil && prof.importsContext() {
+		return nil, fmt.Errorf("%s: could not locate context.Context in the import graph of %s", name, pass.Pkg.Path())
 	}
 
-	s, err := newState(pass, contextIface)
+	s, err := newState(pass, prof, cfg, contextIface)
 	if err != nil {
 		return nil, err
 	}
@@ -245,23 +417,35 @@ func run(pass *analysis.Pass) (any, error) {
 	if err := s.collectFacts(insp); err != nil {
 		return nil, err
 	}
+	s.exportResultFacts()
+	if s.ctxLoggerMode() {
+		s.exportCtxLoggerFacts()
+	}
 
-	// Phase B: check terminal calls.
-	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
-		s.handleCall(n.(*ast.CallExpr))
-	})
+	// Phase B: check terminal calls, or assignments to global loggers.
+	switch chk {
+	case checkCalls:
+		insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+			s.handleCall(n.(*ast.CallExpr))
+		})
+	case checkGlobals:
+		insp.Preorder([]ast.Node{(*ast.AssignStmt)(nil)}, func(n ast.Node) {
+			s.checkGlobalAssign(n.(*ast.AssignStmt))
+		})
+	}
 
 	// A failure to read sources degrades nolint classification (see
 	// isStandaloneComment); make it loud so a misconfigured driver is
 	// noticed instead of silently changing suppression semantics.
 	if s.readErr != nil {
-		return nil, fmt.Errorf("zerologctx: reading source for nolint processing: %w", s.readErr)
+		return nil, fmt.Errorf("%s: reading source for nolint processing: %w", name, s.readErr)
 	}
-	return nil, nil
+	return s.result, nil
 }
 
 // collectFacts runs the fact-collection phase over assignments, var
-// declarations and mutating Event statements, repeated to a fixpoint so facts
+// declarations, struct literals, range statements, type switches, channel
+// sends and mutating Event statements, repeated to a fixpoint so facts
 // that depend on other facts (aliases, package-level declarations in later
 // files) propagate regardless of source order. Hitting maxFactPasses means an
 // out-of-source-order dependency chain deeper than the cap (or a broken
@@ -272,44 +456,69 @@ func (s *state) collectFacts(insp *inspector.Inspector) error {
 		(*ast.AssignStmt)(nil),
 		(*ast.ValueSpec)(nil),
 		(*ast.ExprStmt)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.RangeStmt)(nil),
+		(*ast.TypeSwitchStmt)(nil),
+		(*ast.SendStmt)(nil),
 	}
 	for range maxFactPasses {
 		s.facts.dirty = false
-		insp.Preorder(factNodes, func(n ast.Node) {
-			switch node := n.(type) {
-			case *ast.AssignStmt:
-				s.handleAssign(node)
-			case *ast.ValueSpec:
-				s.handleValueSpec(node)
-			case *ast.ExprStmt:
-				s.handleExprStmt(node)
-			}
-		})
+		insp.Preorder(factNodes, s.collectNode)
+		if s.endContainerPass() {
+			s.facts.dirty = true
+		}
+		if s.updateResultSummaries() {
+			s.facts.dirty = true
+		}
+		if s.ctxLoggerMode() && s.updateCtxLoggers() {
+			s.facts.dirty = true
+		}
 		if !s.facts.dirty {
 			return nil
 		}
 	}
-	return fmt.Errorf("zerologctx: fact propagation did not converge after %d passes", maxFactPasses)
+	return fmt.Errorf("%s: fact propagation did not converge after %d passes", s.pass.Analyzer.Name, maxFactPasses)
 }
 
-// scanImports walks pkg's transitive import graph once, reporting whether
-// zerolog (or one of its sub-packages, e.g. zerolog/log) is imported and
-// locating the standard library's context.Context interface. The walk stops
-// early once both are found.
-func scanImports(pkg *types.Package) (hasZerolog bool, contextIface *types.Interface) {
+// collectNode records the facts established by one of the nodes visited by
+// collectFacts.
+func (s *state) collectNode(n ast.Node) {
+	switch node := n.(type) {
+	case *ast.AssignStmt:
+		s.handleAssign(node)
+	case *ast.ValueSpec:
+		s.handleValueSpec(node)
+	case *ast.ExprStmt:
+		s.handleExprStmt(node)
+	case *ast.CompositeLit:
+		s.handleCompositeLit(node)
+	case *ast.RangeStmt:
+		s.handleRange(node)
+	case *ast.TypeSwitchStmt:
+		s.handleTypeSwitch(node)
+	case *ast.SendStmt:
+		s.handleSend(node)
+	}
+}
+
+// scanImports walks pkg's transitive import graph once, reporting whether the
+// logging package logPkgPath (or one of its sub-packages, e.g. zerolog/log)
+// is imported and locating the standard library's context.Context interface.
+// The walk stops early once both are found.
+func scanImports(pkg *types.Package, logPkgPath string) (hasLog bool, contextIface *types.Interface) {
 	if pkg == nil {
 		return false, nil
 	}
 	seen := map[*types.Package]bool{}
 	var visit func(p *types.Package)
 	visit = func(p *types.Package) {
-		if p == nil || seen[p] || (hasZerolog && contextIface != nil) {
+		if p == nil || seen[p] || (hasLog && contextIface != nil) {
 			return
 		}
 		seen[p] = true
 		switch {
-		case p.Path() == zerologPkgPath || strings.HasPrefix(p.Path(), zerologPkgPath+"/"):
-			hasZerolog = true
+		case p.Path() == logPkgPath || strings.HasPrefix(p.Path(), logPkgPath+"/"):
+			hasLog = true
 		case p.Path() == "context":
 			if obj := p.Scope().Lookup("Context"); obj != nil {
 				if iface, ok := obj.Type().Underlying().(*types.Interface); ok {
@@ -322,7 +531,7 @@ func scanImports(pkg *types.Package) (hasZerolog bool, contextIface *types.Inter
 		}
 	}
 	visit(pkg)
-	return hasZerolog, contextIface
+	return hasLog, contextIface
 }
 
 // factTable records per-object, position-keyed context facts. Lookup follows
@@ -334,21 +543,40 @@ func scanImports(pkg *types.Package) (hasZerolog bool, contextIface *types.Inter
 type factTable struct {
 	entries map[types.Object]map[token.Pos]factKind
 
+	// kindOf is the profile's type classification, used by set to validate
+	// writes.
+	kindOf func(types.Type) trackKind
+
 	// dirty is set by set when a collection pass learns something new; the
 	// fixpoint loop in collectFacts stops when a full pass leaves it false.
 	dirty bool
+
+	// journal records the writes to undo, between begin and rollback;
+	// journalDirty is the dirty flag to restore.
+	journal      []factWrite
+	journalDirty bool
 }
 
-func newFactTable() *factTable {
-	return &factTable{entries: make(map[types.Object]map[token.Pos]factKind)}
+// factWrite is a write recorded by the journal: the entry it replaced, if
+// any.
+type factWrite struct {
+	obj types.Object
+	pos token.Pos
+	old factKind
+	had bool
+}
+
+func newFactTable(kindOf func(types.Type) trackKind) *factTable {
+	return &factTable{entries: make(map[types.Object]map[token.Pos]factKind), kindOf: kindOf}
 }
 
 // set records what a tracked variable holds as of the given position. Writes
 // whose kind does not match the object's track category (the positiveFactFor
 // correspondence) are rejected: they would corrupt lookups that compare
-// against a specific kind.
+// against a specific kind. Interface-typed variables take any kind, that of
+// the tracked value they hold (see ifaceFact).
 func (t *factTable) set(obj types.Object, pos token.Pos, kind factKind) {
-	if kind != factNone && kind != positiveFactFor(trackKindOf(obj.Type())) {
+	if kind != factNone && kind != positiveFactFor(t.kindOf(obj.Type())) && !isIfaceType(obj.Type()) {
 		return
 	}
 	m := t.entries[obj]
@@ -357,16 +585,47 @@ func (t *factTable) set(obj types.Object, pos token.Pos, kind factKind) {
 		t.entries[obj] = m
 	}
 	if old, ok := m[pos]; !ok || old != kind {
+		if t.journal != nil {
+			t.journal = append(t.journal, factWrite{obj, pos, old, ok})
+		}
 		m[pos] = kind
 		t.dirty = true
 	}
 }
 
+// begin starts recording the writes made by set, for a what-if evaluation
+// undone by rollback.
+func (t *factTable) begin() {
+	t.journal = []factWrite{}
+	t.journalDirty = t.dirty
+}
+
+// rollback undoes the writes made since begin, restoring the dirty flag.
+func (t *factTable) rollback() {
+	for i := len(t.journal) - 1; i >= 0; i-- {
+		w := t.journal[i]
+		if w.had {
+			t.entries[w.obj][w.pos] = w.old
+		} else {
+			delete(t.entries[w.obj], w.pos)
+		}
+	}
+	t.journal = nil
+	t.dirty = t.journalDirty
+}
+
 // at returns what the table knows about obj at the given use position.
 func (t *factTable) at(obj types.Object, at token.Pos) factKind {
+	kind, _, _ := t.lookup(obj, at)
+	return kind
+}
+
+// lookup is at also returning the position of the assignment that decided,
+// or ok == false when nothing is recorded for obj.
+func (t *factTable) lookup(obj types.Object, at token.Pos) (kind factKind, pos token.Pos, ok bool) {
 	entries := t.entries[obj]
 	if len(entries) == 0 {
-		return factNone
+		return factNone, token.NoPos, false
 	}
 	nearest, earliest := factNone, factNone
 	var nearestPos, earliestPos token.Pos
@@ -380,22 +639,51 @@ func (t *factTable) at(obj types.Object, at token.Pos) factKind {
 		}
 	}
 	if haveNearest {
-		return nearest
+		return nearest, nearestPos, true
 	}
-	return earliest
+	return earliest, earliestPos, true
 }
 
 // handleAssign records facts established by `:=` and `=` assignments. A
 // tuple assignment (`a, b := fn()`) cannot be split into per-LHS facts, but
-// it still invalidates any previously recorded fact for its targets.
+// it still invalidates any previously recorded fact for its targets — unless
+// the call attaches a context (`l, err := logr.FromContext(ctx)`), which
+// gives every tracked target the context.
 func (s *state) handleAssign(node *ast.AssignStmt) {
 	if len(node.Lhs) != len(node.Rhs) {
+		// l, ok := loggers[k], l, ok := v.(zerolog.Logger), l, ok := <-ch
+		if isCommaOk(node.Rhs[0]) {
+			if obj := s.objectFromExpr(node.Lhs[0]); obj != nil {
+				s.recordRHS(obj, node.Pos(), node.Rhs[0])
+			}
+			return
+		}
+		attached := false
+		if call, ok := ast.Unparen(node.Rhs[0]).(*ast.CallExpr); ok {
+			has, _ := s.summarizedCall(call, node.Pos())
+			attached = has || s.attaches(call, s.calleeRecvKind(call)) == attachCtx
+		}
 		for _, lhs := range node.Lhs {
+			if idx, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok {
+				s.recordIndexStore(idx, node.Pos(), nil)
+				continue
+			}
+			obj := s.objectFromExpr(lhs)
+			if tk := s.trackKindOfObj(obj); attached && tk != trackNone {
+				s.facts.set(obj, node.Pos(), positiveFactFor(tk))
+				continue
+			}
 			s.clearIfTracked(lhs, node.Pos())
 		}
 		return
 	}
 	for i, lhs := range node.Lhs {
+		if idx, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok {
+			if node.Tok == token.ASSIGN {
+				s.recordIndexStore(idx, node.Pos(), node.Rhs[i])
+			}
+			continue
+		}
 		obj := s.objectFromExpr(lhs)
 		if obj == nil {
 			continue
@@ -404,6 +692,19 @@ func (s *state) handleAssign(node *ast.AssignStmt) {
 	}
 }
 
+// isCommaOk reports whether e, the single value of a two-value assignment,
+// is a comma-ok form whose first value is e itself: an index, a type
+// assertion or a receive.
+func isCommaOk(e ast.Expr) bool {
+	switch x := ast.Unparen(e).(type) {
+	case *ast.IndexExpr, *ast.TypeAssertExpr:
+		return true
+	case *ast.UnaryExpr:
+		return x.Op == token.ARROW
+	}
+	return false
+}
+
 // handleValueSpec records facts established by `var` declarations (including
 // package-level vars). A multi-value spec backed by a single call is treated
 // like a tuple assignment.
@@ -412,8 +713,15 @@ func (s *state) handleValueSpec(node *ast.ValueSpec) {
 		return
 	}
 	if len(node.Names) != len(node.Values) {
+		// var l, ok = v.(zerolog.Logger)
+		if isCommaOk(node.Values[0]) {
+			if obj := s.pass.TypesInfo.Defs[node.Names[0]]; obj != nil {
+				s.recordRHS(obj, node.Pos(), node.Values[0])
+			}
+			return
+		}
 		for _, name := range node.Names {
-			if obj := s.pass.TypesInfo.Defs[name]; obj != nil && trackKindOf(obj.Type()) != trackNone {
+			if obj := s.pass.TypesInfo.Defs[name]; obj != nil && s.prof.kindOf(obj.Type()) != trackNone {
 				s.facts.set(obj, node.Pos(), factNone)
 			}
 		}
@@ -428,33 +736,143 @@ func (s *state) handleValueSpec(node *ast.ValueSpec) {
 	}
 }
 
+// handleCompositeLit records the facts established by a struct literal for
+// its tracked fields, keyed (`App{logger: l}`, `Svc{Logger: l}`) or
+// positional (`Svc{l}`). Fields are tracked per declaration, so the literal
+// counts as an assignment to the field for every value of the type.
+func (s *state) handleCompositeLit(node *ast.CompositeLit) {
+	t := s.pass.TypesInfo.TypeOf(node)
+	if t == nil {
+		return
+	}
+	if ptr, ok := t.Underlying().(*types.Pointer); ok { // elided &T in []*T{{...}}
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	for i, elt := range node.Elts {
+		var field types.Object
+		value := elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			key, ok := kv.Key.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			field, value = s.pass.TypesInfo.ObjectOf(key), kv.Value
+		} else if i < st.NumFields() {
+			field = st.Field(i)
+		}
+		if field != nil {
+			s.recordRHS(origin(field), node.Pos(), value)
+		}
+	}
+}
+
 // handleExprStmt records the fact established by a mutating statement such as
 // `e.Ctx(ctx)`: zerolog Event methods mutate the receiver in place and return
 // it, so a discarded chain still attaches the context to the root variable.
+// In-place logger updates are handled by handleUpdate.
 func (s *state) handleExprStmt(node *ast.ExprStmt) {
 	call, ok := ast.Unparen(node.X).(*ast.CallExpr)
 	if !ok {
 		return
 	}
-	if !isZerologEvent(s.pass.TypesInfo.TypeOf(call)) {
+	if s.handleUpdate(call, node.Pos()) {
+		return
+	}
+	if s.prof.kindOf(s.pass.TypesInfo.TypeOf(call)) != trackEvent {
 		return
 	}
 	if !s.eventHasCtx(call, node.Pos()) {
 		return
 	}
 	root := s.chainRootObject(call)
-	if root == nil || trackKindOf(root.Type()) != trackEvent {
+	if root == nil || s.prof.kindOf(root.Type()) != trackEvent {
 		return
 	}
 	s.facts.set(root, node.Pos(), factEventCtx)
 }
 
+// handleUpdate records the fact established by an in-place logger update
+// such as `l.UpdateContext(func(c zerolog.Context) zerolog.Context { return
+// c.Ctx(ctx) })`: when every return of the function literal yields a builder
+// with context, the receiver — a logger variable or field, or the pointer
+// whose target is updated — has context from pos on. An update that returns
+// a builder without context cannot remove one the logger already has, so it
+// records nothing. It reports whether call is an update.
+func (s *state) handleUpdate(call *ast.CallExpr, pos token.Pos) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := typeutil.Callee(s.pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return false
+	}
+	recv := s.recvKind(sel)
+	arg := s.prof.updater(call, fn, recv)
+	if arg == nil {
+		return false
+	}
+	lit, ok := ast.Unparen(arg).(*ast.FuncLit)
+	if !ok || !s.returnsBuilderCtx(lit) {
+		return true
+	}
+	var obj types.Object
+	if f := s.promotedRecv(sel); f != nil { // svc.UpdateContext(...) on an embedded Logger
+		obj = f
+	} else {
+		target := ast.Unparen(sel.X)
+		if star, ok := target.(*ast.StarExpr); ok { // (*p).UpdateContext(...)
+			target = star.X
+		}
+		obj = s.objectFromExpr(target)
+	}
+	if s.trackKindOfObj(obj) == trackLogger {
+		s.facts.set(obj, pos, factLoggerCtx)
+	}
+	return true
+}
+
+// returnsBuilderCtx reports whether lit has at least one return statement
+// and every one of them (outside nested function literals) returns a builder
+// with context.
+func (s *state) returnsBuilderCtx(lit *ast.FuncLit) bool {
+	found, all := false, true
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			found = true
+			if len(n.Results) != 1 || !s.builderHasCtx(n.Results[0], n.Pos()) {
+				all = false
+			}
+		}
+		return all
+	})
+	return found && all
+}
+
 // recordRHS classifies a right-hand-side expression for the given target
 // object. Reassignment to a value without context records factNone, which
 // supersedes any earlier positive fact at later use positions.
 func (s *state) recordRHS(obj types.Object, pos token.Pos, rhs ast.Expr) {
-	tk := trackKindOf(obj.Type())
+	if ek := s.elemKind(obj.Type()); ek != trackNone {
+		s.recordContainer(obj, ek, pos, rhs)
+		return
+	}
+	if s.funcResultKind(obj.Type()) != trackNone {
+		s.recordFuncVar(obj, pos, rhs)
+		return
+	}
+	tk := s.prof.kindOf(obj.Type())
 	if tk == trackNone {
+		if isIfaceType(obj.Type()) {
+			s.facts.set(obj, pos, s.ifaceFact(rhs, pos))
+		}
 		return
 	}
 	kind := factNone
@@ -479,10 +897,19 @@ func (s *state) exprHasCtx(tk trackKind, expr ast.Expr, at token.Pos) bool {
 
 // clearIfTracked records factNone for an assignment target whose type the
 // analyzer tracks (used for tuple assignments, where the RHS value cannot be
-// classified per target).
+// classified per target). Interface-typed variables, which may hold a
+// tracked value, are cleared too, and containers and function variables get
+// a store without context.
 func (s *state) clearIfTracked(lhs ast.Expr, pos token.Pos) {
 	obj := s.objectFromExpr(lhs)
-	if obj == nil || trackKindOf(obj.Type()) == trackNone {
+	if obj == nil {
+		return
+	}
+	if s.elemKind(obj.Type()) != trackNone || s.funcResultKind(obj.Type()) != trackNone {
+		s.storeElem(obj, false)
+		return
+	}
+	if s.prof.kindOf(obj.Type()) == trackNone && !isIfaceType(obj.Type()) {
 		return
 	}
 	s.facts.set(obj, pos, factNone)
@@ -506,39 +933,196 @@ func (s *state) chainRootObject(expr ast.Expr) types.Object {
 	}
 }
 
-// handleCall checks a CallExpr to see whether it is a terminal zerolog call
-// (Msg/Msgf/MsgFunc/Send) on an *Event that lacks an upstream Ctx(ctx).
+// handleCall checks a CallExpr to see whether it is a terminal call (for
+// zerolog, Msg/Msgf/MsgFunc/Send on an *Event) whose value lacks a context.
+// Every terminal call is classified in the pass's Result; findings silenced
+// by a //nolint directive are recorded there instead of being reported.
 func (s *state) handleCall(node *ast.CallExpr) {
 	sel, ok := node.Fun.(*ast.SelectorExpr)
 	if !ok {
 		return
 	}
-	if _, terminal := terminalMethods[sel.Sel.Name]; !terminal {
+	recv := s.recvKind(sel)
+	if recv == trackNone || !s.prof.isTerminal(recv, sel.Sel.Name) {
 		return
 	}
-	recvType := s.pass.TypesInfo.TypeOf(sel.X)
-	if recvType == nil || !isZerologEvent(recvType) {
-		return
+	if s.cfg.explain {
+		s.tracing, s.trace = true, nil
+		defer func() { s.tracing, s.trace = false, nil }()
 	}
-	if s.eventHasCtx(sel.X, node.Pos()) {
+	call := TerminalCall{Pos: node.Pos(), End: node.End(), Method: sel.Sel.Name}
+	if src := s.terminalCtxSource(node, sel, recv); src != SourceNone {
+		call.Status, call.Source, call.Explain = CallHasContext, src, s.trace
+		s.result.Calls = append(s.result.Calls, call)
 		return
 	}
-	if s.hasNoLintDirective(node, sel.Sel.Pos()) {
-		return
+	diag, ok := s.diagnose(node, sel)
+	s.reportMissing(call, node, sel.Sel.Pos(), diag, ok)
+}
+
+// terminalCtxSource reports where the context of a terminal call comes from:
+// the call itself (a context field passed to a zap terminal), the Event it is
+// called on, or the logger it is called on.
+func (s *state) terminalCtxSource(node *ast.CallExpr, sel *ast.SelectorExpr, recv trackKind) ContextSource {
+	if s.attaches(node, recv) == attachCtx {
+		s.note(sel.Sel.Pos(), "%s() is passed a context", sel.Sel.Name)
+		return SourceInlineCtx
 	}
+	switch recv {
+	case trackEvent:
+		return s.recvEventSource(sel, node.Pos())
+	case trackLogger:
+		if s.recvHasCtx(trackLogger, sel, node.Pos()) {
+			s.note(sel.Sel.Pos(), "%s() called on a logger with context", sel.Sel.Name)
+			return SourceLogger
+		}
+		s.note(sel.Sel.Pos(), "%s() called on a logger without context", sel.Sel.Name)
+	}
+	return SourceNone
+}
 
-	// With the real zerolog API only an untyped nil can reach a Ctx() call
-	// without satisfying context.Context; give it a message that does not
-	// falsely claim the Ctx() call is missing.
-	if s.chainHasNonCtxArg(sel.X) {
-		s.pass.Report(analysis.Diagnostic{
-			Pos: node.Pos(),
-			Message: fmt.Sprintf(
-				"zerolog event calls Ctx() with a non-context argument before %s() - pass a context.Context for proper log correlation",
-				sel.Sel.Name,
-			),
+// trackKindOfObj classifies obj's type, or returns trackNone for a nil obj.
+func (s *state) trackKindOfObj(obj types.Object) trackKind {
+	if obj == nil {
+		return trackNone
+	}
+	return s.prof.kindOf(obj.Type())
+}
+
+// calleeRecvKind returns the kind of a method call's receiver, or trackNone
+// for package functions and other calls.
+func (s *state) calleeRecvKind(call *ast.CallExpr) trackKind {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return trackNone
+	}
+	return s.recvKind(sel)
+}
+
+// recvKind returns the kind of the value the method selected by sel is
+// called on: the embedded field it is promoted from, or sel.X.
+func (s *state) recvKind(sel *ast.SelectorExpr) trackKind {
+	if f := s.promotedRecv(sel); f != nil {
+		return s.prof.kindOf(f.Type())
+	}
+	return s.prof.kindOf(s.pass.TypesInfo.TypeOf(sel.X))
+}
+
+// promotedRecv returns the embedded field a method selected by sel is
+// promoted from — the Logger field of `type Svc struct{ zerolog.Logger }`
+// for svc.Info() — or nil when sel.X is the receiver itself. Like other
+// struct fields, the embedded field is tracked per declaration.
+func (s *state) promotedRecv(sel *ast.SelectorExpr) *types.Var {
+	selection, ok := s.pass.TypesInfo.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal || len(selection.Index()) < 2 {
+		return nil
+	}
+	idx := selection.Index()
+	t := selection.Recv()
+	var field *types.Var
+	for _, i := range idx[:len(idx)-1] {
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok || i >= st.NumFields() {
+			return nil
+		}
+		field = st.Field(i)
+		t = field.Type()
+	}
+	if field == nil {
+		return nil
+	}
+	return field.Origin()
+}
+
+// recvHasCtx reports whether the receiver of the method selected by sel, of
+// kind tk, carries a context: the embedded field the method is promoted
+// from, or sel.X.
+func (s *state) recvHasCtx(tk trackKind, sel *ast.SelectorExpr, at token.Pos) bool {
+	if f := s.promotedRecv(sel); f != nil {
+		return s.objFactIs(f, sel.Pos(), at, positiveFactFor(tk))
+	}
+	return s.exprHasCtx(tk, sel.X, at)
+}
+
+// recvEventSource is recvHasCtx for an Event receiver, reporting where its
+// context comes from.
+func (s *state) recvEventSource(sel *ast.SelectorExpr, at token.Pos) ContextSource {
+	if f := s.promotedRecv(sel); f != nil {
+		if s.objFactIs(f, sel.Pos(), at, factEventCtx) {
+			return SourceEventVar
+		}
+		return SourceNone
+	}
+	return s.eventCtxSource(sel.X, at)
+}
+
+// attaches asks the profile whether call attaches a context, given the kind
+// of its receiver (trackNone for package functions and unresolved callees).
+func (s *state) attaches(call *ast.CallExpr, recv trackKind) attachResult {
+	fn := typeutil.Callee(s.pass.TypesInfo, call)
+	f, ok := fn.(*types.Func)
+	if !ok {
+		return attachNone
+	}
+	isCtx := func(e ast.Expr) bool {
+		t := s.pass.TypesInfo.TypeOf(e)
+		return t != nil && s.isContextType(t)
+	}
+	callee := func(c *ast.CallExpr) *types.Func {
+		fn, _ := typeutil.Callee(s.pass.TypesInfo, c).(*types.Func)
+		return fn
+	}
+	return s.prof.attaches(call, f, recv, isCtx, callee)
+}
+
+// reportMissing classifies and records a call whose logger lacks context.
+// ok is false when diagnose found nothing to report (no context available);
+// otherwise diag is reported unless -allow-funcs or a //nolint directive on
+// the lines from the call's start through lastPos suppresses it, in which
+// case it is recorded in Result.Suppressed.
+func (s *state) reportMissing(call TerminalCall, node *ast.CallExpr, lastPos token.Pos, diag analysis.Diagnostic, ok bool) {
+	call.Category = diag.Category
+	switch {
+	case !ok:
+		call.Status, call.Category = CallNoContext, CategoryNoCtxAvailable
+	case s.inAllowedFunc(node.Pos()):
+		call.Status = CallSuppressed
+		fd := s.enclosingFuncDecl(node.Pos())
+		s.note(fd.Name.Pos(), "%s is listed in -allow-funcs - not reported", fd.Name.Name)
+		diag.Related = s.trace
+		s.result.Suppressed = append(s.result.Suppressed, SuppressedDiagnostic{
+			Diagnostic: diag,
+			Directive:  AllowFuncsDirective,
 		})
-		return
+	default:
+		if c := s.noLintDirective(node, lastPos, diag.Category); c != nil {
+			call.Status = CallSuppressed
+			s.note(c.Pos(), "silenced by %s", c.Text)
+			diag.Related = s.trace
+			s.result.Suppressed = append(s.result.Suppressed, SuppressedDiagnostic{
+				Diagnostic: diag,
+				Directive:  c.Text,
+			})
+		} else {
+			call.Status = CallMissingContext
+			diag.Related = s.trace
+			s.pass.Report(diag)
+		}
+	}
+	call.Explain = s.trace
+	s.result.Calls = append(s.result.Calls, call)
+}
+
+// diagnose builds the diagnostic for a terminal call whose value lacks
+// context, or returns false when there is nothing to report.
+func (s *state) diagnose(node *ast.CallExpr, sel *ast.SelectorExpr) (analysis.Diagnostic, bool) {
+	if s.chainHasNonCtxArg(sel.X) {
+		d := s.prof.nonCtxArg(node, sel)
+		d.Category = CategoryNilCtx
+		return d, true
 	}
 
 	// Report only when a context is actually available at the call site — as
@@ -546,23 +1130,14 @@ func (s *state) handleCall(node *ast.CallExpr) {
 	// there is nothing to fix, so stay silent.
 	ctxName, ok := s.findCtxInScope(node.Pos())
 	if !ok {
-		return
+		return analysis.Diagnostic{}, false
 	}
-	s.pass.Report(analysis.Diagnostic{
-		Pos: node.Pos(),
-		Message: fmt.Sprintf(
-			"zerolog event missing .Ctx(ctx) before %s() - context should be included for proper log correlation",
-			sel.Sel.Name,
-		),
-		SuggestedFixes: []analysis.SuggestedFix{{
-			Message: fmt.Sprintf("Insert .Ctx(%s) before %s()", ctxName, sel.Sel.Name),
-			TextEdits: []analysis.TextEdit{{
-				Pos:     sel.Sel.Pos(),
-				End:     sel.Sel.Pos(),
-				NewText: []byte("Ctx(" + ctxName + ")."),
-			}},
-		}},
-	})
+	d := s.prof.missingCtx(node, sel, ctxName)
+	d.Category = CategoryMissingCtx
+	if m := s.ifaceOrigin(sel.X); m != "" {
+		d.Message += fmt.Sprintf(" (created by %s, not every implementation of which is known to have context)", m)
+	}
+	return d, true
 }
 
 // eventHasCtx reports whether expr — an expression of type *zerolog.Event —
@@ -571,28 +1146,52 @@ func (s *state) handleCall(node *ast.CallExpr) {
 // logger. The walk is type-driven, so every Event-producing Logger method
 // (Info, Error, Err, WithLevel, ...) is covered without a method whitelist.
 func (s *state) eventHasCtx(expr ast.Expr, at token.Pos) bool {
+	return s.eventCtxSource(expr, at) != SourceNone
+}
+
+// eventCtxSource is eventHasCtx reporting where the context comes from, or
+// SourceNone.
+func (s *state) eventCtxSource(expr ast.Expr, at token.Pos) ContextSource {
 	expr = ast.Unparen(expr)
 	if call, ok := expr.(*ast.CallExpr); ok {
+		if has, ok := s.summarizedCall(call, at); ok {
+			if has {
+				return SourceHelper
+			}
+			return SourceNone
+		}
 		sel, ok := call.Fun.(*ast.SelectorExpr)
 		if !ok {
-			return false
+			s.note(call.Pos(), "Event returned by %s, which is not followed", types.ExprString(call.Fun))
+			return SourceNone
 		}
-		recv := s.pass.TypesInfo.TypeOf(sel.X)
-		switch {
-		case isZerologEvent(recv):
-			// Event.Ctx(ctx) attaches the context. The Event-receiver check
-			// preserves the load-bearing distinction from Logger lookups like
-			// log.Ctx(ctx), which do NOT attach context to created events.
-			if sel.Sel.Name == "Ctx" && s.callArgIsContext(call) {
-				return true
+		recv := s.recvKind(sel)
+		switch recv {
+		case trackEvent:
+			// Event.Ctx(ctx) attaches the context. The profile's receiver
+			// check preserves the load-bearing distinction from Logger
+			// lookups like log.Ctx(ctx), which do NOT attach context to
+			// created events.
+			if s.attaches(call, recv) == attachCtx {
+				s.note(sel.Sel.Pos(), "%s attaches a context.Context to the Event", methodCallString(call, sel))
+				return SourceInlineCtx
 			}
-			return s.eventHasCtx(sel.X, at)
-		case isZerologLogger(recv):
-			return s.loggerHasCtx(sel.X, at)
+			return s.recvEventSource(sel, at)
+		case trackLogger:
+			if s.recvHasCtx(trackLogger, sel, at) {
+				s.note(sel.Sel.Pos(), "Event created by %s() on a logger with context", sel.Sel.Name)
+				return SourceLogger
+			}
+			s.note(sel.Sel.Pos(), "Event created by %s() on a logger without context", sel.Sel.Name)
+			return SourceNone
 		}
-		return false
+		s.note(call.Pos(), "Event created by %s(), which does not attach a context", types.ExprString(call.Fun))
+		return SourceNone
 	}
-	return s.factIs(expr, at, factEventCtx)
+	if s.factIs(expr, at, factEventCtx) {
+		return SourceEventVar
+	}
+	return SourceNone
 }
 
 // loggerHasCtx reports whether expr — an expression of type zerolog.Logger or
@@ -608,20 +1207,42 @@ func (s *state) loggerHasCtx(expr ast.Expr, at token.Pos) bool {
 		if x.Op == token.AND {
 			return s.loggerHasCtx(x.X, at)
 		}
-		return false
 	case *ast.CallExpr:
+		if has, ok := s.summarizedCall(x, at); ok {
+			return has
+		}
 		sel, ok := x.Fun.(*ast.SelectorExpr)
 		if !ok {
+			s.note(x.Pos(), "logger returned by %s, which is not followed", types.ExprString(x.Fun))
 			return false
 		}
-		recv := s.pass.TypesInfo.TypeOf(sel.X)
-		switch {
-		case isZerologContext(recv):
+		recv := s.recvKind(sel)
+		if s.attaches(x, recv) == attachCtx {
+			// logr.FromContextOrDiscard(ctx), zap's logger.With(ctxField)
+			s.note(x.Pos(), "%s attaches a context.Context to the logger", types.ExprString(x.Fun))
+			return true
+		}
+		if arg := s.loadedLoggerCtx(x, recv); arg != nil && s.ctxLoggerMode() {
+			// zerolog.Ctx(ctx) under -ctx-loggers
+			if s.carriesLogger(arg, at) {
+				s.note(x.Pos(), "%s returns the logger stored in %s, which has context", types.ExprString(x.Fun), types.ExprString(arg))
+				return true
+			}
+			s.note(x.Pos(), "%s is not known to carry a logger with context, and the global logger returned for a context without one is not trusted", types.ExprString(arg))
+			return false
+		}
+		switch recv {
+		case trackBuilder:
 			// builder.Logger()
-			return s.builderHasCtx(sel.X, at)
-		case isZerologLogger(recv):
+			return s.recvHasCtx(trackBuilder, sel, at)
+		case trackLogger:
 			// Logger-to-Logger derivation keeps the embedded context.
-			return s.loggerHasCtx(sel.X, at)
+			return s.recvHasCtx(trackLogger, sel, at)
+		}
+		if sel.Sel.Name == "Ctx" {
+			s.note(x.Pos(), "%s returns the logger stored in the context; it does not attach the context to events", types.ExprString(x.Fun))
+		} else {
+			s.note(x.Pos(), "logger returned by %s, which is not followed", types.ExprString(x.Fun))
 		}
 		return false
 	}
@@ -635,22 +1256,27 @@ func (s *state) loggerHasCtx(expr ast.Expr, at token.Pos) bool {
 func (s *state) builderHasCtx(expr ast.Expr, at token.Pos) bool {
 	expr = ast.Unparen(expr)
 	if call, ok := expr.(*ast.CallExpr); ok {
+		if has, ok := s.summarizedCall(call, at); ok {
+			return has
+		}
 		sel, ok := call.Fun.(*ast.SelectorExpr)
 		if !ok {
 			return false
 		}
-		recv := s.pass.TypesInfo.TypeOf(sel.X)
-		switch {
-		case isZerologContext(recv):
-			if sel.Sel.Name == "Ctx" && s.callArgIsContext(call) {
+		recv := s.recvKind(sel)
+		switch recv {
+		case trackBuilder:
+			if s.attaches(call, recv) == attachCtx {
+				s.note(sel.Sel.Pos(), "%s attaches a context.Context to the logger builder", methodCallString(call, sel))
 				return true
 			}
-			return s.builderHasCtx(sel.X, at)
-		case isZerologLogger(recv):
+			return s.recvHasCtx(trackBuilder, sel, at)
+		case trackLogger:
 			// logger.With() — a builder seeded from the logger, inheriting
 			// its embedded context.
-			return s.loggerHasCtx(sel.X, at)
+			return s.recvHasCtx(trackLogger, sel, at)
 		}
+		s.note(call.Pos(), "logger builder returned by %s, which does not attach a context", types.ExprString(call.Fun))
 		return false
 	}
 	return s.factIs(expr, at, factBuilderCtx)
@@ -660,8 +1286,57 @@ func (s *state) builderHasCtx(expr ast.Expr, at token.Pos) bool {
 // the given position is exactly kind. Shared base case of the three
 // predicates, making the predicate↔fact-kind correspondence explicit.
 func (s *state) factIs(expr ast.Expr, at token.Pos, kind factKind) bool {
+	switch x := ast.Unparen(expr).(type) {
+	case *ast.IndexExpr:
+		return s.elemFactIs(x.X, x.Pos(), kind)
+	case *ast.UnaryExpr: // <-ch
+		if x.Op == token.ARROW {
+			return s.elemFactIs(x.X, x.Pos(), kind)
+		}
+	case *ast.TypeAssertExpr: // v.(zerolog.Logger)
+		return s.factIs(x.X, at, kind)
+	}
 	obj := s.objectFromExpr(expr)
-	return obj != nil && s.facts.at(obj, at) == kind
+	if obj == nil {
+		s.note(expr.Pos(), "%s is not a tracked variable", types.ExprString(expr))
+		return false
+	}
+	return s.objFactIs(obj, expr.Pos(), at, kind)
+}
+
+// objFactIs is factIs for obj, resolved from the expression at use. The
+// library's global loggers are never trusted (see checkGlobalAssign).
+func (s *state) objFactIs(obj types.Object, use, at token.Pos, kind factKind) bool {
+	if name := s.globalLogger(obj); name != "" {
+		s.note(use, "%s is a global logger; a context installed in it is not trusted", name)
+		return false
+	}
+	got, pos, ok := s.facts.lookup(obj, at)
+	if !ok {
+		s.note(use, "%s has no recorded assignment", obj.Name())
+		return false
+	}
+	s.note(pos, "%s holds %s as of this assignment", obj.Name(), got)
+	return got == kind
+}
+
+// methodCallString prints a method call without its receiver, e.g. Ctx(ctx)
+// for e.Str("k", "v").Ctx(ctx), for -explain output.
+func methodCallString(call *ast.CallExpr, sel *ast.SelectorExpr) string {
+	args := make([]string, len(call.Args))
+	for i, a := range call.Args {
+		args[i] = types.ExprString(a)
+	}
+	return sel.Sel.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// note records one step of the reasoning behind the call being classified,
+// when running under -explain.
+func (s *state) note(pos token.Pos, format string, args ...any) {
+	if !s.tracing {
+		return
+	}
+	s.trace = append(s.trace, analysis.RelatedInformation{Pos: pos, Message: fmt.Sprintf(format, args...)})
 }
 
 // callArgIsContext reports whether the call's first argument satisfies
@@ -674,9 +1349,9 @@ func (s *state) callArgIsContext(call *ast.CallExpr) bool {
 	return argType != nil && s.isContextType(argType)
 }
 
-// chainHasNonCtxArg reports whether the Event chain contains a Ctx() call on
-// an Event receiver whose argument does not satisfy context.Context (with the
-// real zerolog API this means an untyped nil).
+// chainHasNonCtxArg reports whether the Event chain contains an attaching
+// call (zerolog's Event.Ctx) whose argument does not satisfy context.Context
+// (with the real zerolog API this means an untyped nil).
 func (s *state) chainHasNonCtxArg(expr ast.Expr) bool {
 	for {
 		expr = ast.Unparen(expr)
@@ -688,7 +1363,9 @@ func (s *state) chainHasNonCtxArg(expr ast.Expr) bool {
 		if !ok {
 			return false
 		}
-		if sel.Sel.Name == "Ctx" && isZerologEvent(s.pass.TypesInfo.TypeOf(sel.X)) && !s.callArgIsContext(call) {
+		recv := s.recvKind(sel)
+		if recv == trackEvent && s.attaches(call, recv) == attachNonCtx {
+			s.note(sel.Sel.Pos(), "Ctx() argument does not satisfy context.Context")
 			return true
 		}
 		expr = sel.X
@@ -714,66 +1391,54 @@ func (s *state) isContextType(typ types.Type) bool {
 	return false
 }
 
-// isZerologNamed reports whether t (or its pointer element) is the named type
-// zerologPkgPath.name. Comparing the defining package path avoids matching
-// similarly named types from forks or unrelated packages.
-func isZerologNamed(t types.Type, name string) bool {
-	if t == nil {
-		return false
-	}
-	if ptr, ok := t.(*types.Pointer); ok {
-		t = ptr.Elem()
-	}
-	named, ok := t.(*types.Named)
-	if !ok {
-		return false
-	}
-	obj := named.Obj()
-	if obj == nil || obj.Pkg() == nil {
-		return false
-	}
-	return obj.Name() == name && obj.Pkg().Path() == zerologPkgPath
-}
-
-func isZerologEvent(t types.Type) bool   { return isZerologNamed(t, "Event") }
-func isZerologLogger(t types.Type) bool  { return isZerologNamed(t, "Logger") }
-func isZerologContext(t types.Type) bool { return isZerologNamed(t, "Context") }
-
 // objectFromExpr resolves the *types.Object behind a bare identifier or a
 // selector expression (struct field, package-qualified variable). Returns nil
 // for any other shape.
 func (s *state) objectFromExpr(expr ast.Expr) types.Object {
 	switch x := ast.Unparen(expr).(type) {
 	case *ast.Ident:
-		return s.pass.TypesInfo.ObjectOf(x)
+		return origin(s.pass.TypesInfo.ObjectOf(x))
 	case *ast.SelectorExpr:
 		if sel, ok := s.pass.TypesInfo.Selections[x]; ok {
-			return sel.Obj()
+			return origin(sel.Obj())
 		}
-		return s.pass.TypesInfo.ObjectOf(x.Sel)
+		return origin(s.pass.TypesInfo.ObjectOf(x.Sel))
 	}
 	return nil
 }
 
-// hasNoLintDirective reports whether a //nolint comment suppressing
-// zerologctx applies to the given call: a directive on any of the chain's own
-// lines (chain start through the line of the terminal method's name, covering
-// both single-line calls and multi-line fluent chains), or a standalone
-// comment on the line immediately above the chain. An end-of-line comment
-// trailing the previous statement is deliberately not honoured — it belongs
-// to that statement.
-func (s *state) hasNoLintDirective(call *ast.CallExpr, terminalPos token.Pos) bool {
+// origin maps the field or method of an instantiated generic type to the
+// declared one, so facts recorded through holder[int] apply to holder[T].
+func origin(obj types.Object) types.Object {
+	switch o := obj.(type) {
+	case *types.Var:
+		return o.Origin()
+	case *types.Func:
+		return o.Origin()
+	}
+	return obj
+}
+
+// noLintDirective returns the //nolint comment suppressing the running
+// analyzer (or its given diagnostic category) that applies to the given call
+// (or statement), or nil if there is none: a directive on any of the chain's
+// own lines (chain start through the line of terminalPos — the terminal
+// method's name for a zerolog chain, covering both single-line calls and
+// multi-line fluent chains), or a standalone comment on the line immediately
+// above the chain. An end-of-line comment trailing the previous statement is
+// deliberately not honoured — it belongs to that statement.
+func (s *state) noLintDirective(call ast.Node, terminalPos token.Pos, category string) *ast.Comment {
 	// Positions that cannot be matched to an analysed file (cgo-remapped
 	// positions are the only realistic case after newState verified the
 	// FileSet) fail open in the reporting direction: an extra diagnostic is
 	// recoverable noise, a silently honoured-or-dropped nolint is not.
 	tokFile := s.pass.Fset.File(call.Pos())
 	if tokFile == nil {
-		return false
+		return nil
 	}
 	astFile := s.fileFor(tokFile)
 	if astFile == nil {
-		return false
+		return nil
 	}
 
 	chainStart := tokFile.Line(call.Pos())
@@ -782,17 +1447,17 @@ func (s *state) hasNoLintDirective(call *ast.CallExpr, terminalPos token.Pos) bo
 
 	for line := chainStart; line <= terminalLine; line++ {
 		for _, c := range byLine[line] {
-			if isNoLintComment(c.Text, "zerologctx") {
-				return true
+			if isNoLintComment(c.Text, s.pass.Analyzer.Name, category) {
+				return c
 			}
 		}
 	}
 	for _, c := range byLine[chainStart-1] {
-		if s.isStandaloneComment(tokFile, c) && isNoLintComment(c.Text, "zerologctx") {
-			return true
+		if s.isStandaloneComment(tokFile, c) && isNoLintComment(c.Text, s.pass.Analyzer.Name, category) {
+			return c
 		}
 	}
-	return false
+	return nil
 }
 
 // commentsByLine returns (building and caching on first use) a line-indexed
@@ -862,15 +1527,16 @@ func (s *state) fileFor(tf *token.File) *ast.File {
 }
 
 // isNoLintComment reports whether a comment is a nolint directive that
-// applies to linterName. It accepts:
+// applies to linterName's diagnostics of the given category. It accepts:
 //
-//   - //nolint                       (bare, suppresses all linters)
-//   - //nolint:all                   (explicit "all")
+//   - //nolint                            (bare, suppresses all linters)
+//   - //nolint:all                        (explicit "all")
 //   - //nolint:zerologctx
-//   - // nolint: zerologctx          (whitespace variants)
-//   - //nolint:l1,zerologctx,l2      (comma-separated lists)
-//   - //nolint:zerologctx // reason  (trailing reason after second //)
-func isNoLintComment(commentText, linterName string) bool {
+//   - // nolint: zerologctx               (whitespace variants)
+//   - //nolint:l1,zerologctx,l2           (comma-separated lists)
+//   - //nolint:zerologctx // reason       (trailing reason after second //)
+//   - //nolint:zerologctx/missing-ctx     (only that category)
+func isNoLintComment(commentText, linterName, category string) bool {
 	text := strings.TrimSpace(strings.TrimPrefix(commentText, "//"))
 	// Strip any trailing reason that uses a second // separator.
 	if idx := strings.Index(text, "//"); idx >= 0 {
@@ -896,6 +1562,9 @@ func isNoLintComment(commentText, linterName string) bool {
 		if l == linterName || l == "all" {
 			return true
 		}
+		if name, cat, ok := strings.Cut(l, "/"); ok && name == linterName && cat == category && cat != "" {
+			return true
+		}
 	}
 	return false
 }
@@ -932,8 +1601,8 @@ func (s *state) noInitVarSet() map[types.Object]bool {
 // otherwise the nearest preceding candidate in the innermost scope that has
 // one is used. Package-level candidates are usable regardless of declaration
 // order. Variables declared without an initializer are skipped. When no scope
-// variable qualifies, a context-typed field of the enclosing method's
-// receiver (as "recv.field") is used as a last resort. Returns "", false if
+// variable qualifies, a context reachable through the enclosing method's
+// receiver (see receiverCtxField) is used as a last resort. Returns "", false if
 // no candidate exists.
 func (s *state) findCtxInScope(pos token.Pos) (string, bool) {
 	if s.contextIface == nil {
@@ -965,10 +1634,12 @@ func (s *state) findCtxInScope(pos token.Pos) (string, bool) {
 	}
 
 	fallback := ""
+	var fallbackPos token.Pos
 	for sc := scope; sc != nil; sc = sc.Parent() {
 		// Prefer a variable literally named "ctx", even from an outer scope.
 		if obj := sc.Lookup("ctx"); obj != nil {
 			if v, ok := obj.(*types.Var); ok && usable(v, sc) {
+				s.note(v.Pos(), "context candidate ctx chosen (the name ctx is preferred)")
 				return "ctx", true
 			}
 		}
@@ -993,51 +1664,172 @@ func (s *state) findCtxInScope(pos token.Pos) (string, bool) {
 				bestName, bestPos, bestPreceding = name, v.Pos(), preceding
 			}
 		}
-		fallback = bestName
+		fallback, fallbackPos = bestName, bestPos
 	}
 	if fallback != "" {
+		s.note(fallbackPos, "context candidate %s chosen (nearest context.Context variable in scope)", fallback)
 		return fallback, true
 	}
-	return s.receiverCtxField(astFile, pos)
+	if name, ok := s.receiverCtxField(pos); ok {
+		return name, true
+	}
+	s.note(pos, "no context.Context variable, parameter or receiver field in scope - not reported")
+	return "", false
 }
 
-// receiverCtxField looks for a context-typed field on the receiver of the
-// method enclosing pos and returns it as a "recv.field" selector. Calls
-// inside a FuncLit nested in a method still resolve to that method's
-// receiver. Only direct struct fields are considered, not fields promoted
-// from embedded structs; an embedded context.Context itself counts (as
-// "recv.Context").
-func (s *state) receiverCtxField(astFile *ast.File, pos token.Pos) (string, bool) {
+// enclosingFuncDecl returns the top-level function or method declaration
+// containing pos, or nil when pos is outside any FuncDecl (package-level var
+// initialisers). Calls inside a FuncLit resolve to the FuncDecl around it.
+func (s *state) enclosingFuncDecl(pos token.Pos) *ast.FuncDecl {
+	tokFile := s.pass.Fset.File(pos)
+	if tokFile == nil {
+		return nil
+	}
+	astFile := s.fileFor(tokFile)
+	if astFile == nil {
+		return nil
+	}
 	for _, decl := range astFile.Decls {
-		fd, ok := decl.(*ast.FuncDecl)
-		if !ok || fd.Recv == nil || pos < fd.Pos() || pos >= fd.End() {
-			continue
-		}
-		if len(fd.Recv.List) != 1 || len(fd.Recv.List[0].Names) != 1 {
-			return "", false
+		if fd, ok := decl.(*ast.FuncDecl); ok && pos >= fd.Pos() && pos < fd.End() {
+			return fd
 		}
-		recvIdent := fd.Recv.List[0].Names[0]
-		if recvIdent.Name == "_" {
-			return "", false
-		}
-		obj := s.pass.TypesInfo.Defs[recvIdent]
-		if obj == nil {
-			return "", false
+	}
+	return nil
+}
+
+// inAllowedFunc reports whether the FuncDecl enclosing pos is listed in
+// -allow-funcs, i.e. code that intentionally logs without a context even
+// when one is in scope (main, init, TestMain, command run functions).
+func (s *state) inAllowedFunc(pos token.Pos) bool {
+	fd := s.enclosingFuncDecl(pos)
+	if fd == nil {
+		return false
+	}
+	fn, _ := s.pass.TypesInfo.Defs[fd.Name].(*types.Func)
+	return s.cfg.allowFuncs.matches(fn)
+}
+
+// maxCtxFieldDepth bounds how deep receiverCtxField descends into embedded
+// and nested struct fields of the receiver.
+const maxCtxFieldDepth = 3
+
+// receiverCtxField looks for a context available through the receiver of the
+// method enclosing pos and returns it as a selector expression. Calls inside
+// a FuncLit nested in a method still resolve to that method's receiver.
+//
+// Fields are searched breadth-first, so a direct field ("recv.ctx") wins over
+// one promoted from an embedded struct or reached through a nested struct
+// field, which is spelled out in full ("recv.Service.ctx"), up to
+// maxCtxFieldDepth levels. Fields of other packages must be exported, and
+// only embedded fields are followed through pointers, so the fix does not
+// dereference a pointer field that may be nil. An embedded context.Context
+// itself counts (as "recv.Context"). Failing any field, a Context or Ctx
+// method of the receiver taking no arguments and returning only a context is
+// used ("recv.Context()"), other than the enclosing method itself.
+func (s *state) receiverCtxField(pos token.Pos) (string, bool) {
+	fd := s.enclosingFuncDecl(pos)
+	if fd == nil || fd.Recv == nil {
+		return "", false
+	}
+	if len(fd.Recv.List) != 1 || len(fd.Recv.List[0].Names) != 1 {
+		return "", false
+	}
+	recvIdent := fd.Recv.List[0].Names[0]
+	if recvIdent.Name == "_" {
+		return "", false
+	}
+	obj := s.pass.TypesInfo.Defs[recvIdent]
+	if obj == nil {
+		return "", false
+	}
+	if path, f, ok := s.ctxFieldPath(obj.Type()); ok {
+		name := recvIdent.Name + "." + path
+		s.note(f.Pos(), "context candidate %s chosen (context-typed receiver field)", name)
+		return name, true
+	}
+	if m := s.ctxMethod(obj.Type(), s.pass.TypesInfo.Defs[fd.Name]); m != nil {
+		name := recvIdent.Name + "." + m.Name() + "()"
+		s.note(m.Pos(), "context candidate %s chosen (context-returning receiver method)", name)
+		return name, true
+	}
+	return "", false
+}
+
+// ctxFieldPath returns the selector path (without the receiver) of the
+// shallowest accessible context-typed field of t, a struct or a pointer to
+// one, and the field itself. Pointer-typed fields are only followed when
+// embedded.
+func (s *state) ctxFieldPath(t types.Type) (string, *types.Var, bool) {
+	type level struct {
+		t    types.Type
+		path string
+	}
+	seen := make(map[types.Type]bool)
+	current := []level{{t, ""}}
+	for range maxCtxFieldDepth {
+		var next []level
+		for _, l := range current {
+			typ := l.t
+			if ptr, ok := typ.(*types.Pointer); ok {
+				typ = ptr.Elem()
+			}
+			if seen[typ] {
+				continue
+			}
+			seen[typ] = true
+			st, ok := typ.Underlying().(*types.Struct)
+			if !ok {
+				continue
+			}
+			for f := range st.Fields() {
+				if !f.Exported() && f.Pkg() != s.pass.Pkg {
+					continue
+				}
+				path := f.Name()
+				if l.path != "" {
+					path = l.path + "." + path
+				}
+				if s.isContextType(f.Type()) {
+					return path, f, true
+				}
+				if _, isPtr := f.Type().(*types.Pointer); isPtr && !f.Embedded() {
+					continue
+				}
+				next = append(next, level{f.Type(), path})
+			}
 		}
-		t := obj.Type()
-		if ptr, ok := t.(*types.Pointer); ok {
-			t = ptr.Elem()
+		current = next
+	}
+	return "", nil, false
+}
+
+// ctxMethodNames are the names of the receiver methods ctxMethod accepts,
+// in order of preference. Other context-returning methods, such as a
+// Background() fallback, are not taken for the receiver's context.
+var ctxMethodNames = []string{"Context", "Ctx"}
+
+// ctxMethod returns the first method of t (a receiver, so pointer methods
+// are callable) named in ctxMethodNames that takes no arguments and returns
+// only a context, skipping self, the enclosing method (compared by origin,
+// so that a generic receiver's instantiated methods match it).
+func (s *state) ctxMethod(t types.Type, self types.Object) *types.Func {
+	if _, ok := t.(*types.Pointer); !ok {
+		t = types.NewPointer(t)
+	}
+	mset := types.NewMethodSet(t)
+	for _, name := range ctxMethodNames {
+		sel := mset.Lookup(s.pass.Pkg, name)
+		if sel == nil {
+			continue
 		}
-		st, ok := t.Underlying().(*types.Struct)
-		if !ok {
-			return "", false
+		m, ok := sel.Obj().(*types.Func)
+		if !ok || m.Origin() == self {
+			continue
 		}
-		for f := range st.Fields() {
-			if s.isContextType(f.Type()) {
-				return recvIdent.Name + "." + f.Name(), true
-			}
+		sig := m.Signature()
+		if sig.Params().Len() == 0 && sig.Results().Len() == 1 && s.isContextType(sig.Results().At(0).Type()) {
+			return m
 		}
-		return "", false
 	}
-	return "", false
+	return nil
 }
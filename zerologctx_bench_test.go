@@ -38,7 +38,7 @@ func BenchmarkIsNoLintComment(b *testing.B) {
 		b.Run(tc.name, func(b *testing.B) {
 			b.ResetTimer()
 			for b.Loop() {
-				_ = isNoLintComment(tc.comment, tc.linter)
+				_ = isNoLintComment(tc.comment, tc.linter, CategoryMissingCtx)
 			}
 		})
 	}
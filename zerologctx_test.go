@@ -2,10 +2,14 @@
 package zerologctx
 
 import (
+	"fmt"
 	"go/token"
 	"go/types"
+	"slices"
+	"strings"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -22,7 +26,8 @@ func TestAnalyzer(t *testing.T) {
 	// noctxpkg has neither zerolog nor "context" in its import graph and
 	// must be skipped without diagnostics or errors, and scopepkg pins the
 	// context-availability gate (no reachable context — no diagnostic).
-	analysistest.Run(t, testdata, Analyzer, "testpkg", "logonlypkg", "wrapperconsumer", "noctxpkg", "scopepkg")
+	// ifacepkg calls through interfaces implemented in a dependency.
+	analysistest.Run(t, testdata, Analyzer, "testpkg", "logonlypkg", "wrapperconsumer", "noctxpkg", "scopepkg", "ifacepkg")
 }
 
 // TestSuggestedFixes verifies the suggested-fix output end-to-end: candidate
@@ -32,6 +37,255 @@ func TestSuggestedFixes(t *testing.T) {
 	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "fixpkg")
 }
 
+// TestAllowFuncs verifies that -allow-funcs silences calls inside listed
+// functions, matched by exact full name or by /regexp/.
+func TestAllowFuncs(t *testing.T) {
+	setFlag(t, "allow-funcs", `allowpkg.run,(*allowpkg.server).start,/\.(init|setup[A-Z]\w*)$/`)
+	results := analysistest.Run(t, analysistest.TestData(), Analyzer, "allowpkg")
+
+	t.Run("allowed findings are recorded as suppressed", func(t *testing.T) {
+		res := results[0].Result.(*Result)
+		var suppressed int
+		for _, c := range res.Calls {
+			if c.Status == CallSuppressed {
+				suppressed++
+			}
+		}
+		if suppressed == 0 || len(res.Suppressed) != suppressed {
+			t.Fatalf("got %d suppressed diagnostics for %d suppressed calls, want one each", len(res.Suppressed), suppressed)
+		}
+		for _, sd := range res.Suppressed {
+			if sd.Directive != AllowFuncsDirective {
+				t.Errorf("suppressed %q by %q, want %q", sd.Diagnostic.Message, sd.Directive, AllowFuncsDirective)
+			}
+		}
+	})
+
+	t.Run("invalid regexp is rejected", func(t *testing.T) {
+		var p funcPatterns
+		if err := p.Set("/(unclosed/"); err == nil {
+			t.Error("Set accepted an invalid regexp")
+		}
+	})
+
+	t.Run("each analyzer has its own list", func(t *testing.T) {
+		for _, a := range Analyzers() {
+			if a != Analyzer && a.Flags.Lookup("allow-funcs") != nil {
+				if got := a.Flags.Lookup("allow-funcs").Value.String(); got != "" {
+					t.Errorf("%s allow-funcs = %q, want it unaffected by zerologctx's", a.Name, got)
+				}
+			}
+		}
+	})
+
+	t.Run("String round-trips through Set", func(t *testing.T) {
+		const value = ` a.run, /\.(a|b){1,2}$/ ,/x\/,y/,/^\(\*a\.T\)\.[A-Z]/, b.stop `
+		want := []string{`a.run`, `/\.(a|b){1,2}$/`, `/x\/,y/`, `/^\(\*a\.T\)\.[A-Z]/`, `b.stop`}
+		var p funcPatterns
+		if err := p.Set(value); err != nil {
+			t.Fatalf("Set(%q): %v", value, err)
+		}
+		if !slices.Equal(p.raw, want) {
+			t.Fatalf("Set(%q) parsed %q, want %q", value, p.raw, want)
+		}
+		var q funcPatterns
+		if err := q.Set(p.String()); err != nil {
+			t.Fatalf("Set(%q): %v", p.String(), err)
+		}
+		if !slices.Equal(q.raw, want) || len(q.regexps) != 3 {
+			t.Errorf("Set(String()) parsed %q (%d regexps), want %q (3 regexps)", q.raw, len(q.regexps), want)
+		}
+	})
+}
+
+// TestSlogAnalyzer runs the slogctx analyzer, including its fixes to the
+// *Context variants, and checks its -allow-funcs.
+func TestSlogAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), SlogAnalyzer, "slogpkg")
+
+	setAnalyzerFlag(t, SlogAnalyzer, "allow-funcs", "slogallowpkg.run")
+	analysistest.Run(t, analysistest.TestData(), SlogAnalyzer, "slogallowpkg")
+}
+
+// TestLibraryProfiles runs the analyzers built on the logr and zap profiles
+// against their fixtures, with zappkg's own field helper listed by
+// -ctx-fields.
+func TestLibraryProfiles(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), LogrAnalyzer, "logrpkg")
+	setAnalyzerFlag(t, ZapAnalyzer, "ctx-fields", "zappkg.ctxField")
+	analysistest.Run(t, analysistest.TestData(), ZapAnalyzer, "zappkg")
+}
+
+// TestResultCalls verifies that every terminal call is classified in the
+// analyzer's Result, with the source of the context when it has one.
+func TestResultCalls(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), Analyzer, "coveragepkg")
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	res, ok := results[0].Result.(*Result)
+	if !ok {
+		t.Fatalf("Result has type %T, want *Result", results[0].Result)
+	}
+	want := []string{
+		"Msg has-context inline-ctx",
+		"Msg has-context contextual-logger",
+		"Send has-context tracked-event",
+		"Msg missing-context none",
+		"Msg suppressed none",
+		"Msg no-context-available none",
+	}
+	var got []string
+	for _, c := range res.Calls {
+		got = append(got, c.Method+" "+c.Status.String()+" "+c.Source.String())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("calls =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(res.Suppressed) != 1 {
+		t.Errorf("got %d suppressed diagnostics, want 1", len(res.Suppressed))
+	}
+}
+
+// TestCategories verifies the Category of reported diagnostics and of the
+// calls recorded in the Result, and that //nolint:zerologctx/CATEGORY
+// silences only that category.
+func TestCategories(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), Analyzer, "categorypkg")
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	var got []string
+	for _, d := range results[0].Diagnostics {
+		got = append(got, d.Category)
+	}
+	want := []string{CategoryMissingCtx, CategoryNilCtx, CategoryMissingCtx}
+	if !slices.Equal(got, want) {
+		t.Errorf("diagnostic categories = %q, want %q", got, want)
+	}
+
+	res := results[0].Result.(*Result)
+	got = nil
+	for _, c := range res.Calls {
+		got = append(got, c.Status.String()+" "+c.Category)
+	}
+	want = []string{
+		"has-context ",
+		"missing-context missing-ctx",
+		"missing-context nil-ctx",
+		"suppressed missing-ctx",
+		"suppressed nil-ctx",
+		"missing-context missing-ctx",
+		"no-context-available no-ctx-available",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("calls =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// TestUndecoratedCtxAnalyzer verifies the undecoratedctx analyzer: contexts
+// passed to Ctx() must come from the -require-ctx-decorators decorator, a
+// parameter, or a derivation of either, and the check is off without the
+// flag.
+func TestUndecoratedCtxAnalyzer(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		setAnalyzerFlag(t, UndecoratedCtxAnalyzer, "require-ctx-decorators", "decoratorpkg/requestid.With")
+		analysistest.Run(t, analysistest.TestData(), UndecoratedCtxAnalyzer, "decoratorpkg")
+	})
+	t.Run("disabled", func(t *testing.T) {
+		analysistest.Run(t, analysistest.TestData(), UndecoratedCtxAnalyzer, "decoratoroffpkg")
+	})
+}
+
+// TestGlobalCtxLoggerAnalyzer runs the globalctxlogger analyzer.
+func TestGlobalCtxLoggerAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), GlobalCtxLoggerAnalyzer, "globalpkg")
+}
+
+// TestCtxLoggers verifies -ctx-loggers: zerolog.Ctx(ctx) has context when
+// ctx carries a logger with context, stored in the function, returned by a
+// function with a fact or passed to a callback of a middleware with one or
+// of -trusted-middleware (which enables the mode on its own).
+func TestCtxLoggers(t *testing.T) {
+	setFlag(t, "trusted-middleware", "ctxloggerpkg/middleware.Handle")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "ctxloggerpkg")
+}
+
+// TestExplain verifies the reasoning recorded under -explain: the deciding
+// assignment in the fact table, Logger lookups that do not attach context,
+// and the context candidate chosen by findCtxInScope.
+func TestExplain(t *testing.T) {
+	setFlag(t, "explain", "true")
+	results := analysistest.Run(t, analysistest.TestData(), Analyzer, "explainpkg")
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	fset := results[0].Action.Package.Fset
+	res := results[0].Result.(*Result)
+	want := [][]string{
+		{
+			"12:2: l holds a logger with context as of this assignment",
+			"13:4: Event created by Info() on a logger with context",
+		},
+		{
+			"17:2: log.Ctx returns the logger stored in the context; it does not attach the context to events",
+			"17:15: Event created by Info() on a logger without context",
+			"16:13: context candidate ctx chosen (the name ctx is preferred)",
+		},
+		{
+			"22:2: l holds a value without context as of this assignment",
+			"23:4: Event created by Info() on a logger without context",
+			"20:17: context candidate parent chosen (nearest context.Context variable in scope)",
+		},
+		{
+			"31:2: Event created by log.Info(), which does not attach a context",
+			"27:2: context candidate w.reqCtx chosen (context-typed receiver field)",
+		},
+		{
+			"35:2: Event created by log.Info(), which does not attach a context",
+			"35:2: no context.Context variable, parameter or receiver field in scope - not reported",
+		},
+	}
+	if len(res.Calls) != len(want) {
+		t.Fatalf("got %d calls, want %d", len(res.Calls), len(want))
+	}
+	for i, c := range res.Calls {
+		var got []string
+		for _, step := range c.Explain {
+			posn := fset.Position(step.Pos)
+			got = append(got, fmt.Sprintf("%d:%d: %s", posn.Line, posn.Column, step.Message))
+		}
+		if !slices.Equal(got, want[i]) {
+			t.Errorf("call %d explained as\n%s\nwant\n%s", i, strings.Join(got, "\n"), strings.Join(want[i], "\n"))
+		}
+	}
+}
+
+// setFlag sets an Analyzer flag for the duration of the test, restoring the
+// previous value on cleanup so other tests see the defaults.
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+	setAnalyzerFlag(t, Analyzer, name, value)
+}
+
+// setAnalyzerFlag is setFlag for a flag of a.
+func setAnalyzerFlag(t *testing.T, a *analysis.Analyzer, name, value string) {
+	t.Helper()
+	f := a.Flags.Lookup(name)
+	if f == nil {
+		t.Fatalf("unknown %s flag %q", a.Name, name)
+	}
+	prev := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatalf("setting -%s=%q: %v", name, value, err)
+	}
+	t.Cleanup(func() {
+		if err := f.Value.Set(prev); err != nil {
+			t.Errorf("restoring -%s=%q: %v", name, prev, err)
+		}
+	})
+}
+
 // TestIsContextType directly tests the isContextType method against synthetic
 // go/types constructs. This exercises cases that cannot be expressed in the
 // testdata fixtures because the stub's Ctx(context.Context) parameter rejects
@@ -172,11 +426,17 @@ func TestAnalyzerHelpers(t *testing.T) {
 			{"//nolint:ZerolOGCTX", "zerologctx", false},             // case sensitive linter names
 			{"//nolint // reason without colon", "zerologctx", true}, // bare nolint is valid
 			{"/* nolint:zerologctx */", "zerologctx", false},         // block comments are not nolint directives
+			// Category-scoped directives (the diagnostic is missing-ctx)
+			{"//nolint:zerologctx/missing-ctx", "zerologctx", true},
+			{"//nolint:l1, zerologctx/missing-ctx // reason", "zerologctx", true},
+			{"//nolint:zerologctx/nil-ctx", "zerologctx", false},  // other category
+			{"//nolint:slogctx/missing-ctx", "zerologctx", false}, // other linter
+			{"//nolint:zerologctx/", "zerologctx", false},
 		}
 
 		for _, tc := range testCases {
 			t.Run(tc.comment, func(t *testing.T) {
-				got := isNoLintComment(tc.comment, tc.linter)
+				got := isNoLintComment(tc.comment, tc.linter, CategoryMissingCtx)
 				if got != tc.expected {
 					t.Errorf("isNoLintComment(%q, %q) = %v, want %v", tc.comment, tc.linter, got, tc.expected)
 				}
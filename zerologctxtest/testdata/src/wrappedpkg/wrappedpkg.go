@@ -0,0 +1,30 @@
+// Package wrappedpkg is the kind of fixture a project writes for its own
+// logging helpers.
+package wrappedpkg
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Service keeps a logger that carries its context.
+type Service struct {
+	logger zerolog.Logger
+}
+
+func NewService(ctx context.Context) *Service {
+	s := &Service{}
+	s.logger = log.With().Ctx(ctx).Logger()
+	return s
+}
+
+func (s *Service) Handle(ctx context.Context) {
+	s.logger.Info().Msg("field logger with context")
+	log.Info().Msg("global logger") // want `zerolog event missing .Ctx\(ctx\) before Msg\(\)`
+}
+
+func startup(ctx context.Context) {
+	log.Info().Msg("startup") // want `zerolog event missing .Ctx\(ctx\) before Msg\(\)`
+}
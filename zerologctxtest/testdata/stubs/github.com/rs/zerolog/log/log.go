@@ -0,0 +1,50 @@
+// Code generated by internal/stubgen from github.com/rs/zerolog/log v1.35.1. DO NOT EDIT.
+
+// Package log is a signature-complete stub of github.com/rs/zerolog/log for testing.
+package log
+
+import (
+	"context"
+	"github.com/rs/zerolog"
+	"io"
+)
+
+var (
+	Logger zerolog.Logger
+)
+
+func Ctx(ctx context.Context) *zerolog.Logger { panic("stub") }
+
+func Debug() *zerolog.Event { panic("stub") }
+
+func Err(err error) *zerolog.Event { panic("stub") }
+
+func Error() *zerolog.Event { panic("stub") }
+
+func Fatal() *zerolog.Event { panic("stub") }
+
+func Hook(h zerolog.Hook) zerolog.Logger { panic("stub") }
+
+func Info() *zerolog.Event { panic("stub") }
+
+func Level(level zerolog.Level) zerolog.Logger { panic("stub") }
+
+func Log() *zerolog.Event { panic("stub") }
+
+func Output(w io.Writer) zerolog.Logger { panic("stub") }
+
+func Panic() *zerolog.Event { panic("stub") }
+
+func Print(v ...interface{}) {}
+
+func Printf(format string, v ...interface{}) {}
+
+func Sample(s zerolog.Sampler) zerolog.Logger { panic("stub") }
+
+func Trace() *zerolog.Event { panic("stub") }
+
+func Warn() *zerolog.Event { panic("stub") }
+
+func With() zerolog.Context { panic("stub") }
+
+func WithLevel(level zerolog.Level) *zerolog.Event { panic("stub") }
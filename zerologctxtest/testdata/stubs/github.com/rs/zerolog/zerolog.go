@@ -0,0 +1,667 @@
+// Code generated by internal/stubgen from github.com/rs/zerolog v1.35.1. DO NOT EDIT.
+
+// Package zerolog is a signature-complete stub of github.com/rs/zerolog for testing.
+package zerolog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"time"
+)
+
+const (
+	DebugLevel           Level = 0
+	Disabled             Level = 7
+	DurationFormatFloat        = "float"
+	DurationFormatInt          = "int"
+	DurationFormatString       = "string"
+	ErrorLevel           Level = 3
+	FatalLevel           Level = 4
+	InfoLevel            Level = 1
+	NoLevel              Level = 6
+	PanicLevel           Level = 5
+	TimeFormatUnix             = ""
+	TimeFormatUnixMicro        = "UNIXMICRO"
+	TimeFormatUnixMs           = "UNIXMS"
+	TimeFormatUnixNano         = "UNIXNANO"
+	TraceLevel           Level = -1
+	WarnLevel            Level = 2
+)
+
+var (
+	CallerFieldName                    string
+	CallerMarshalFunc                  func(pc uintptr, file string, line int) string
+	CallerSkipFrameCount               int
+	DefaultContextLogger               *Logger
+	DurationFieldFormat                string
+	DurationFieldInteger               bool
+	DurationFieldUnit                  time.Duration
+	ErrorFieldName                     string
+	ErrorHandler                       func(err error)
+	ErrorMarshalFunc                   func(err error) interface{}
+	ErrorStackFieldName                string
+	ErrorStackMarshaler                func(err error) interface{}
+	FatalExitFunc                      func()
+	FloatingPointPrecision             int
+	FormattedLevels                    map[Level]string
+	InterfaceMarshalFunc               func(v interface{}) ([]byte, error)
+	LevelColors                        map[Level]int
+	LevelDebugValue                    string
+	LevelErrorValue                    string
+	LevelFatalValue                    string
+	LevelFieldMarshalFunc              func(l Level) string
+	LevelFieldName                     string
+	LevelInfoValue                     string
+	LevelPanicValue                    string
+	LevelTraceValue                    string
+	LevelWarnValue                     string
+	MessageFieldName                   string
+	Often                              RandomSampler
+	Rarely                             RandomSampler
+	Sometimes                          RandomSampler
+	TimeFieldFormat                    string
+	TimestampFieldName                 string
+	TimestampFunc                      func() time.Time
+	TriggerLevelWriterBufferReuseLimit int
+)
+
+func Arr() *Array { panic("stub") }
+
+type Array struct {
+}
+
+func (a *Array) Bool(b bool) *Array { panic("stub") }
+
+func (a *Array) Bytes(val []byte) *Array { panic("stub") }
+
+func (a *Array) Dict(dict *Event) *Array { panic("stub") }
+
+func (a *Array) Dur(d time.Duration) *Array { panic("stub") }
+
+func (a *Array) Err(err error) *Array { panic("stub") }
+
+func (a *Array) Errs(errs []error) *Array { panic("stub") }
+
+func (a *Array) Float32(f float32) *Array { panic("stub") }
+
+func (a *Array) Float64(f float64) *Array { panic("stub") }
+
+func (a *Array) Hex(val []byte) *Array { panic("stub") }
+
+func (a *Array) IPAddr(ip net.IP) *Array { panic("stub") }
+
+func (a *Array) IPPrefix(pfx net.IPNet) *Array { panic("stub") }
+
+func (a *Array) Int(i int) *Array { panic("stub") }
+
+func (a *Array) Int16(i int16) *Array { panic("stub") }
+
+func (a *Array) Int32(i int32) *Array { panic("stub") }
+
+func (a *Array) Int64(i int64) *Array { panic("stub") }
+
+func (a *Array) Int8(i int8) *Array { panic("stub") }
+
+func (a *Array) Interface(i interface{}) *Array { panic("stub") }
+
+func (a *Array) MACAddr(ha net.HardwareAddr) *Array { panic("stub") }
+
+func (_ *Array) MarshalZerologArray(*Array) {}
+
+func (a *Array) Object(obj LogObjectMarshaler) *Array { panic("stub") }
+
+func (a *Array) RawJSON(val []byte) *Array { panic("stub") }
+
+func (a *Array) Str(val string) *Array { panic("stub") }
+
+func (a *Array) Time(t time.Time) *Array { panic("stub") }
+
+func (a *Array) Type(val interface{}) *Array { panic("stub") }
+
+func (a *Array) Uint(i uint) *Array { panic("stub") }
+
+func (a *Array) Uint16(i uint16) *Array { panic("stub") }
+
+func (a *Array) Uint32(i uint32) *Array { panic("stub") }
+
+func (a *Array) Uint64(i uint64) *Array { panic("stub") }
+
+func (a *Array) Uint8(i uint8) *Array { panic("stub") }
+
+func AsLogObjectMarshalers[T LogObjectMarshaler](objs []T) []LogObjectMarshaler { panic("stub") }
+
+func AsStringers[T fmt.Stringer](objs []T) []fmt.Stringer { panic("stub") }
+
+type BasicSampler struct {
+	N uint32
+}
+
+func (s *BasicSampler) Sample(lvl Level) bool { panic("stub") }
+
+type BurstSampler struct {
+	Burst       uint32
+	Period      time.Duration
+	NextSampler Sampler
+}
+
+func (s *BurstSampler) Sample(lvl Level) bool { panic("stub") }
+
+func ConsoleTestWriter(t TestingLog) func(w *ConsoleWriter) { panic("stub") }
+
+type ConsoleWriter struct {
+	Out                   io.Writer
+	NoColor               bool
+	TimeFormat            string
+	TimeLocation          *time.Location
+	PartsOrder            []string
+	PartsExclude          []string
+	FieldsOrder           []string
+	FieldsExclude         []string
+	FormatTimestamp       Formatter
+	FormatLevel           Formatter
+	FormatCaller          Formatter
+	FormatMessage         Formatter
+	FormatFieldName       Formatter
+	FormatFieldValue      Formatter
+	FormatErrFieldName    Formatter
+	FormatErrFieldValue   Formatter
+	FormatPartValueByName FormatterByFieldName
+	FormatExtra           func(map[string]interface{}, *bytes.Buffer) error
+	FormatPrepare         func(map[string]interface{}) error
+}
+
+func (w ConsoleWriter) Close() error { panic("stub") }
+
+func (w ConsoleWriter) Write(p []byte) (n int, err error) { panic("stub") }
+
+type Context struct {
+}
+
+func (c Context) AnErr(key string, err error) Context { panic("stub") }
+
+func (c Context) Any(key string, i interface{}) Context { panic("stub") }
+
+func (c Context) Array(key string, arr LogArrayMarshaler) Context { panic("stub") }
+
+func (c Context) Bool(key string, b bool) Context { panic("stub") }
+
+func (c Context) Bools(key string, b []bool) Context { panic("stub") }
+
+func (c Context) Bytes(key string, val []byte) Context { panic("stub") }
+
+func (c Context) Caller() Context { panic("stub") }
+
+func (c Context) CallerWithSkipFrameCount(skipFrameCount int) Context { panic("stub") }
+
+func (c Context) CreateArray() *Array { panic("stub") }
+
+func (c Context) CreateDict() *Event { panic("stub") }
+
+func (c Context) Ctx(ctx context.Context) Context { panic("stub") }
+
+func (c Context) Dict(key string, dict *Event) Context { panic("stub") }
+
+func (c Context) Dur(key string, d time.Duration) Context { panic("stub") }
+
+func (c Context) Durs(key string, d []time.Duration) Context { panic("stub") }
+
+func (c Context) EmbedObject(obj LogObjectMarshaler) Context { panic("stub") }
+
+func (c Context) Err(err error) Context { panic("stub") }
+
+func (c Context) Errs(key string, errs []error) Context { panic("stub") }
+
+func (c Context) Fields(fields interface{}) Context { panic("stub") }
+
+func (c Context) Float32(key string, f float32) Context { panic("stub") }
+
+func (c Context) Float64(key string, f float64) Context { panic("stub") }
+
+func (c Context) Floats32(key string, f []float32) Context { panic("stub") }
+
+func (c Context) Floats64(key string, f []float64) Context { panic("stub") }
+
+func (c Context) Hex(key string, val []byte) Context { panic("stub") }
+
+func (c Context) IPAddr(key string, ip net.IP) Context { panic("stub") }
+
+func (c Context) IPAddrs(key string, ip []net.IP) Context { panic("stub") }
+
+func (c Context) IPPrefix(key string, pfx net.IPNet) Context { panic("stub") }
+
+func (c Context) IPPrefixes(key string, pfx []net.IPNet) Context { panic("stub") }
+
+func (c Context) Int(key string, i int) Context { panic("stub") }
+
+func (c Context) Int16(key string, i int16) Context { panic("stub") }
+
+func (c Context) Int32(key string, i int32) Context { panic("stub") }
+
+func (c Context) Int64(key string, i int64) Context { panic("stub") }
+
+func (c Context) Int8(key string, i int8) Context { panic("stub") }
+
+func (c Context) Interface(key string, i interface{}) Context { panic("stub") }
+
+func (c Context) Ints(key string, i []int) Context { panic("stub") }
+
+func (c Context) Ints16(key string, i []int16) Context { panic("stub") }
+
+func (c Context) Ints32(key string, i []int32) Context { panic("stub") }
+
+func (c Context) Ints64(key string, i []int64) Context { panic("stub") }
+
+func (c Context) Ints8(key string, i []int8) Context { panic("stub") }
+
+func (c Context) Logger() Logger { panic("stub") }
+
+func (c Context) MACAddr(key string, ha net.HardwareAddr) Context { panic("stub") }
+
+func (c Context) Object(key string, obj LogObjectMarshaler) Context { panic("stub") }
+
+func (c Context) Objects(key string, objs []LogObjectMarshaler) Context { panic("stub") }
+
+func (c Context) ObjectsV(key string, objs ...LogObjectMarshaler) Context { panic("stub") }
+
+func (c Context) RawJSON(key string, b []byte) Context { panic("stub") }
+
+func (c Context) Reset() Context { panic("stub") }
+
+func (c Context) Stack() Context { panic("stub") }
+
+func (c Context) Str(key string, val string) Context { panic("stub") }
+
+func (c Context) Stringer(key string, val fmt.Stringer) Context { panic("stub") }
+
+func (c Context) Stringers(key string, vals []fmt.Stringer) Context { panic("stub") }
+
+func (c Context) StringersV(key string, vals ...fmt.Stringer) Context { panic("stub") }
+
+func (c Context) Strs(key string, vals []string) Context { panic("stub") }
+
+func (c Context) StrsV(key string, vals ...string) Context { panic("stub") }
+
+func (c Context) Time(key string, t time.Time) Context { panic("stub") }
+
+func (c Context) Times(key string, t []time.Time) Context { panic("stub") }
+
+func (c Context) Timestamp() Context { panic("stub") }
+
+func (c Context) Type(key string, val interface{}) Context { panic("stub") }
+
+func (c Context) Uint(key string, i uint) Context { panic("stub") }
+
+func (c Context) Uint16(key string, i uint16) Context { panic("stub") }
+
+func (c Context) Uint32(key string, i uint32) Context { panic("stub") }
+
+func (c Context) Uint64(key string, i uint64) Context { panic("stub") }
+
+func (c Context) Uint8(key string, i uint8) Context { panic("stub") }
+
+func (c Context) Uints(key string, i []uint) Context { panic("stub") }
+
+func (c Context) Uints16(key string, i []uint16) Context { panic("stub") }
+
+func (c Context) Uints32(key string, i []uint32) Context { panic("stub") }
+
+func (c Context) Uints64(key string, i []uint64) Context { panic("stub") }
+
+func (c Context) Uints8(key string, i []uint8) Context { panic("stub") }
+
+func Ctx(ctx context.Context) *Logger { panic("stub") }
+
+func Dict() *Event { panic("stub") }
+
+func DisableSampling(v bool) {}
+
+type Event struct {
+}
+
+func (e *Event) AnErr(key string, err error) *Event { panic("stub") }
+
+func (e *Event) Any(key string, i interface{}) *Event { panic("stub") }
+
+func (e *Event) Array(key string, arr LogArrayMarshaler) *Event { panic("stub") }
+
+func (e *Event) Bool(key string, b bool) *Event { panic("stub") }
+
+func (e *Event) Bools(key string, b []bool) *Event { panic("stub") }
+
+func (e *Event) Bytes(key string, val []byte) *Event { panic("stub") }
+
+func (e *Event) Caller(skip ...int) *Event { panic("stub") }
+
+func (e *Event) CallerSkipFrame(skip int) *Event { panic("stub") }
+
+func (e *Event) CreateArray() *Array { panic("stub") }
+
+func (e *Event) CreateDict() *Event { panic("stub") }
+
+func (e *Event) Ctx(ctx context.Context) *Event { panic("stub") }
+
+func (e *Event) Dict(key string, dict *Event) *Event { panic("stub") }
+
+func (e *Event) Discard() *Event { panic("stub") }
+
+func (e *Event) Dur(key string, d time.Duration) *Event { panic("stub") }
+
+func (e *Event) Durs(key string, d []time.Duration) *Event { panic("stub") }
+
+func (e *Event) EmbedObject(obj LogObjectMarshaler) *Event { panic("stub") }
+
+func (e *Event) Enabled() bool { panic("stub") }
+
+func (e *Event) Err(err error) *Event { panic("stub") }
+
+func (e *Event) Errs(key string, errs []error) *Event { panic("stub") }
+
+func (e *Event) Fields(fields interface{}) *Event { panic("stub") }
+
+func (e *Event) Float32(key string, f float32) *Event { panic("stub") }
+
+func (e *Event) Float64(key string, f float64) *Event { panic("stub") }
+
+func (e *Event) Floats32(key string, f []float32) *Event { panic("stub") }
+
+func (e *Event) Floats64(key string, f []float64) *Event { panic("stub") }
+
+func (e *Event) Func(f func(e *Event)) *Event { panic("stub") }
+
+func (e *Event) GetCtx() context.Context { panic("stub") }
+
+func (e *Event) Hex(key string, val []byte) *Event { panic("stub") }
+
+func (e *Event) IPAddr(key string, ip net.IP) *Event { panic("stub") }
+
+func (e *Event) IPAddrs(key string, ip []net.IP) *Event { panic("stub") }
+
+func (e *Event) IPPrefix(key string, pfx net.IPNet) *Event { panic("stub") }
+
+func (e *Event) IPPrefixes(key string, pfx []net.IPNet) *Event { panic("stub") }
+
+func (e *Event) Int(key string, i int) *Event { panic("stub") }
+
+func (e *Event) Int16(key string, i int16) *Event { panic("stub") }
+
+func (e *Event) Int32(key string, i int32) *Event { panic("stub") }
+
+func (e *Event) Int64(key string, i int64) *Event { panic("stub") }
+
+func (e *Event) Int8(key string, i int8) *Event { panic("stub") }
+
+func (e *Event) Interface(key string, i interface{}) *Event { panic("stub") }
+
+func (e *Event) Ints(key string, i []int) *Event { panic("stub") }
+
+func (e *Event) Ints16(key string, i []int16) *Event { panic("stub") }
+
+func (e *Event) Ints32(key string, i []int32) *Event { panic("stub") }
+
+func (e *Event) Ints64(key string, i []int64) *Event { panic("stub") }
+
+func (e *Event) Ints8(key string, i []int8) *Event { panic("stub") }
+
+func (e *Event) MACAddr(key string, ha net.HardwareAddr) *Event { panic("stub") }
+
+func (e *Event) Msg(msg string) {}
+
+func (e *Event) MsgFunc(createMsg func() string) {}
+
+func (e *Event) Msgf(format string, v ...interface{}) {}
+
+func (e *Event) Object(key string, obj LogObjectMarshaler) *Event { panic("stub") }
+
+func (e *Event) Objects(key string, objs []LogObjectMarshaler) *Event { panic("stub") }
+
+func (e *Event) ObjectsV(key string, objs ...LogObjectMarshaler) *Event { panic("stub") }
+
+func (e *Event) RawCBOR(key string, b []byte) *Event { panic("stub") }
+
+func (e *Event) RawJSON(key string, b []byte) *Event { panic("stub") }
+
+func (e *Event) Send() {}
+
+func (e *Event) Stack() *Event { panic("stub") }
+
+func (e *Event) Str(key string, val string) *Event { panic("stub") }
+
+func (e *Event) Stringer(key string, val fmt.Stringer) *Event { panic("stub") }
+
+func (e *Event) Stringers(key string, vals []fmt.Stringer) *Event { panic("stub") }
+
+func (e *Event) StringersV(key string, vals ...fmt.Stringer) *Event { panic("stub") }
+
+func (e *Event) Strs(key string, vals []string) *Event { panic("stub") }
+
+func (e *Event) StrsV(key string, vals ...string) *Event { panic("stub") }
+
+func (e *Event) Time(key string, t time.Time) *Event { panic("stub") }
+
+func (e *Event) TimeDiff(key string, t time.Time, start time.Time) *Event { panic("stub") }
+
+func (e *Event) Times(key string, t []time.Time) *Event { panic("stub") }
+
+func (e *Event) Timestamp() *Event { panic("stub") }
+
+func (e *Event) Type(key string, val interface{}) *Event { panic("stub") }
+
+func (e *Event) Uint(key string, i uint) *Event { panic("stub") }
+
+func (e *Event) Uint16(key string, i uint16) *Event { panic("stub") }
+
+func (e *Event) Uint32(key string, i uint32) *Event { panic("stub") }
+
+func (e *Event) Uint64(key string, i uint64) *Event { panic("stub") }
+
+func (e *Event) Uint8(key string, i uint8) *Event { panic("stub") }
+
+func (e *Event) Uints(key string, i []uint) *Event { panic("stub") }
+
+func (e *Event) Uints16(key string, i []uint16) *Event { panic("stub") }
+
+func (e *Event) Uints32(key string, i []uint32) *Event { panic("stub") }
+
+func (e *Event) Uints64(key string, i []uint64) *Event { panic("stub") }
+
+func (e *Event) Uints8(key string, i []uint8) *Event { panic("stub") }
+
+type FilteredLevelWriter struct {
+	Writer LevelWriter
+	Level  Level
+}
+
+func (w *FilteredLevelWriter) Close() error { panic("stub") }
+
+func (w *FilteredLevelWriter) Write(p []byte) (int, error) { panic("stub") }
+
+func (w *FilteredLevelWriter) WriteLevel(level Level, p []byte) (int, error) { panic("stub") }
+
+type Formatter func(interface{}) string
+
+type FormatterByFieldName func(interface{}, string) string
+
+func GlobalLevel() Level { panic("stub") }
+
+type Hook interface {
+	Run(e *Event, level Level, message string)
+}
+
+type HookFunc func(e *Event, level Level, message string)
+
+func (h HookFunc) Run(e *Event, level Level, message string) {}
+
+type Level int8
+
+func (l Level) MarshalText() ([]byte, error) { panic("stub") }
+
+func (l Level) String() string { panic("stub") }
+
+func (l *Level) UnmarshalText(text []byte) error { panic("stub") }
+
+type LevelHook struct {
+	NoLevelHook Hook
+	TraceHook   Hook
+	DebugHook   Hook
+	InfoHook    Hook
+	WarnHook    Hook
+	ErrorHook   Hook
+	FatalHook   Hook
+	PanicHook   Hook
+}
+
+func (h LevelHook) Run(e *Event, level Level, message string) {}
+
+type LevelSampler struct {
+	TraceSampler Sampler
+	DebugSampler Sampler
+	InfoSampler  Sampler
+	WarnSampler  Sampler
+	ErrorSampler Sampler
+}
+
+func (s LevelSampler) Sample(lvl Level) bool { panic("stub") }
+
+type LevelWriter interface {
+	WriteLevel(level Level, p []byte) (n int, err error)
+	io.Writer
+}
+
+type LevelWriterAdapter struct {
+	io.Writer
+}
+
+func (lw LevelWriterAdapter) Close() error { panic("stub") }
+
+func (lw LevelWriterAdapter) WriteLevel(l Level, p []byte) (n int, err error) { panic("stub") }
+
+type LogArrayMarshaler interface{ MarshalZerologArray(a *Array) }
+
+type LogObjectMarshaler interface{ MarshalZerologObject(e *Event) }
+
+type Logger struct {
+}
+
+func (l *Logger) Debug() *Event { panic("stub") }
+
+func (l *Logger) Err(err error) *Event { panic("stub") }
+
+func (l *Logger) Error() *Event { panic("stub") }
+
+func (l *Logger) Fatal() *Event { panic("stub") }
+
+func (l Logger) GetLevel() Level { panic("stub") }
+
+func (l Logger) Hook(hooks ...Hook) Logger { panic("stub") }
+
+func (l *Logger) Info() *Event { panic("stub") }
+
+func (l Logger) Level(lvl Level) Logger { panic("stub") }
+
+func (l *Logger) Log() *Event { panic("stub") }
+
+func (l Logger) Output(w io.Writer) Logger { panic("stub") }
+
+func (l *Logger) Panic() *Event { panic("stub") }
+
+func (l *Logger) Print(v ...interface{}) {}
+
+func (l *Logger) Printf(format string, v ...interface{}) {}
+
+func (l *Logger) Println(v ...interface{}) {}
+
+func (l Logger) Sample(s Sampler) Logger { panic("stub") }
+
+func (l *Logger) Trace() *Event { panic("stub") }
+
+func (l *Logger) UpdateContext(update func(c Context) Context) {}
+
+func (l *Logger) Warn() *Event { panic("stub") }
+
+func (l Logger) With() Context { panic("stub") }
+
+func (l Logger) WithContext(ctx context.Context) context.Context { panic("stub") }
+
+func (l *Logger) WithLevel(level Level) *Event { panic("stub") }
+
+func (l Logger) Write(p []byte) (n int, err error) { panic("stub") }
+
+func MultiLevelWriter(writers ...io.Writer) LevelWriter { panic("stub") }
+
+func New(w io.Writer) Logger { panic("stub") }
+
+func NewConsoleWriter(options ...func(w *ConsoleWriter)) ConsoleWriter { panic("stub") }
+
+func NewLevelHook() LevelHook { panic("stub") }
+
+func NewSlogHandler(logger Logger) *SlogHandler { panic("stub") }
+
+func NewTestWriter(t TestingLog) TestWriter { panic("stub") }
+
+func Nop() Logger { panic("stub") }
+
+func ParseLevel(levelStr string) (Level, error) { panic("stub") }
+
+type RandomSampler uint32
+
+func (s RandomSampler) Sample(lvl Level) bool { panic("stub") }
+
+type Sampler interface{ Sample(lvl Level) bool }
+
+func SetGlobalLevel(l Level) {}
+
+type SlogHandler struct {
+}
+
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool { panic("stub") }
+
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error { panic("stub") }
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler { panic("stub") }
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler { panic("stub") }
+
+func SyncWriter(w io.Writer) io.Writer { panic("stub") }
+
+func SyslogCEEWriter(w SyslogWriter) LevelWriter { panic("stub") }
+
+func SyslogLevelWriter(w SyslogWriter) LevelWriter { panic("stub") }
+
+type SyslogWriter interface {
+	Crit(m string) error
+	Debug(m string) error
+	Emerg(m string) error
+	Err(m string) error
+	Info(m string) error
+	Warning(m string) error
+	io.Writer
+}
+
+type TestWriter struct {
+	T     TestingLog
+	Frame int
+}
+
+func (t TestWriter) Write(p []byte) (n int, err error) { panic("stub") }
+
+type TestingLog interface {
+	Helper()
+	Log(args ...interface{})
+	Logf(format string, args ...interface{})
+}
+
+type TriggerLevelWriter struct {
+	io.Writer
+	ConditionalLevel Level
+	TriggerLevel     Level
+}
+
+func (w *TriggerLevelWriter) Close() error { panic("stub") }
+
+func (w *TriggerLevelWriter) Trigger() error { panic("stub") }
+
+func (w *TriggerLevelWriter) WriteLevel(l Level, p []byte) (n int, err error) { panic("stub") }
@@ -0,0 +1,125 @@
+// Package zap is a stub implementation of go.uber.org/zap for testing
+package zap
+
+// Field is a typed log field (zapcore.Field in the real library)
+type Field struct {
+	Key       string
+	Interface any
+}
+
+// Any builds a Field from an arbitrary value
+func Any(key string, value any) Field {
+	return Field{Key: key, Interface: value}
+}
+
+// Reflect builds a Field serialised by reflection
+func Reflect(key string, value any) Field {
+	return Field{Key: key, Interface: value}
+}
+
+// String builds a string Field
+func String(key string, value string) Field {
+	return Field{Key: key, Interface: value}
+}
+
+// Error builds an error Field
+func Error(err error) Field {
+	return Field{Key: "error", Interface: err}
+}
+
+// Option configures a Logger
+type Option interface{}
+
+// Logger is zap's structured logger
+type Logger struct{}
+
+// NewNop returns a no-op Logger
+func NewNop() *Logger {
+	return &Logger{}
+}
+
+// NewProduction returns a production Logger
+func NewProduction(opts ...Option) (*Logger, error) {
+	return &Logger{}, nil
+}
+
+// L returns the global Logger
+func L() *Logger {
+	return &Logger{}
+}
+
+// S returns the global SugaredLogger
+func S() *SugaredLogger {
+	return &SugaredLogger{}
+}
+
+// With returns a child Logger with the fields added
+func (l *Logger) With(fields ...Field) *Logger {
+	return l
+}
+
+// Named returns a child Logger with the name segment added
+func (l *Logger) Named(name string) *Logger {
+	return l
+}
+
+// WithOptions returns a clone of the Logger with the options applied
+func (l *Logger) WithOptions(opts ...Option) *Logger {
+	return l
+}
+
+// Sugar wraps the Logger in a SugaredLogger
+func (l *Logger) Sugar() *SugaredLogger {
+	return &SugaredLogger{}
+}
+
+// Sync flushes buffered entries
+func (l *Logger) Sync() error {
+	return nil
+}
+
+// Debug logs a message at debug level
+func (l *Logger) Debug(msg string, fields ...Field) {}
+
+// Info logs a message at info level
+func (l *Logger) Info(msg string, fields ...Field) {}
+
+// Warn logs a message at warn level
+func (l *Logger) Warn(msg string, fields ...Field) {}
+
+// Error logs a message at error level
+func (l *Logger) Error(msg string, fields ...Field) {}
+
+// DPanic logs a message at dpanic level
+func (l *Logger) DPanic(msg string, fields ...Field) {}
+
+// Panic logs a message and panics
+func (l *Logger) Panic(msg string, fields ...Field) {}
+
+// Fatal logs a message and exits
+func (l *Logger) Fatal(msg string, fields ...Field) {}
+
+// SugaredLogger is zap's loosely typed logger
+type SugaredLogger struct{}
+
+// With returns a child SugaredLogger with the key/value pairs added
+func (s *SugaredLogger) With(args ...any) *SugaredLogger {
+	return s
+}
+
+// Desugar unwraps the SugaredLogger
+func (s *SugaredLogger) Desugar() *Logger {
+	return &Logger{}
+}
+
+// Info logs the arguments at info level
+func (s *SugaredLogger) Info(args ...any) {}
+
+// Infof logs a formatted message at info level
+func (s *SugaredLogger) Infof(template string, args ...any) {}
+
+// Infow logs a message with key/value pairs at info level
+func (s *SugaredLogger) Infow(msg string, keysAndValues ...any) {}
+
+// Errorw logs a message with key/value pairs at error level
+func (s *SugaredLogger) Errorw(msg string, keysAndValues ...any) {}
@@ -0,0 +1,178 @@
+// Package zerologctxtest runs the zerologctx analyzers over test fixtures, for
+// projects checking that the analyzer understands their own logging helpers.
+// It wraps analysistest with the library stubs the analyzer's own tests use
+// (github.com/rs/zerolog and github.com/rs/zerolog/log, plus
+// github.com/go-logr/logr and go.uber.org/zap) pre-installed, so fixtures
+// import them without vendoring a copy.
+//
+// Fixtures are laid out as analysistest expects — dir/src/PKG/*.go, with
+// "// want" comments and optional .golden files — and are copied with the
+// stubs into a temporary GOPATH for each run. A fixture providing its own
+// copy of a stub package overrides the bundled one.
+//
+// The analyzers' flags are process-wide, so tests using a Config with flags
+// or a policy file must not run in parallel with each other.
+package zerologctxtest
+
+import (
+	"embed"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/tolmachov/zerologctx"
+)
+
+// stubRoot is the directory of the embedded stubs, laid out as a GOPATH src
+// directory.
+const stubRoot = "testdata/stubs"
+
+//go:embed testdata/stubs
+var stubs embed.FS
+
+// Testing is the subset of testing.TB the helpers need.
+type Testing interface {
+	analysistest.Testing
+	Helper()
+	Fatalf(format string, args ...any)
+	TempDir() string
+	Cleanup(func())
+}
+
+// Config selects the analyzer and the settings a run uses. The zero Config
+// runs zerologctx.Analyzer with its current settings.
+type Config struct {
+	// Analyzer is the analyzer to run; nil means zerologctx.Analyzer.
+	Analyzer *analysis.Analyzer
+
+	// ConfigFile is a .zerologctx.yml or .json policy file applied before
+	// Flags, as the zerologctx command does.
+	ConfigFile string
+
+	// Flags maps analyzer flag names (allow-funcs, explain, ...) to values.
+	Flags map[string]string
+}
+
+// Run runs zerologctx.Analyzer on the packages matching patterns in the
+// fixture directory dir, checking its "// want" expectations.
+func Run(t Testing, dir string, patterns ...string) []*analysistest.Result {
+	t.Helper()
+	return Config{}.Run(t, dir, patterns...)
+}
+
+// RunWithSuggestedFixes is Run that also checks the suggested fixes against
+// the fixtures' .golden files.
+func RunWithSuggestedFixes(t Testing, dir string, patterns ...string) []*analysistest.Result {
+	t.Helper()
+	return Config{}.RunWithSuggestedFixes(t, dir, patterns...)
+}
+
+// Run is the package-level Run with c's analyzer and settings. The settings
+// are restored when the test ends.
+func (c Config) Run(t Testing, dir string, patterns ...string) []*analysistest.Result {
+	t.Helper()
+	a := c.apply(t)
+	return analysistest.Run(t, gopath(t, dir), a, patterns...)
+}
+
+// RunWithSuggestedFixes is the package-level RunWithSuggestedFixes with c's
+// analyzer and settings.
+func (c Config) RunWithSuggestedFixes(t Testing, dir string, patterns ...string) []*analysistest.Result {
+	t.Helper()
+	a := c.apply(t)
+	return analysistest.RunWithSuggestedFixes(t, gopath(t, dir), a, patterns...)
+}
+
+// apply applies c's settings, arranging for the previous values to be
+// restored, and returns the analyzer to run.
+func (c Config) apply(t Testing) *analysis.Analyzer {
+	t.Helper()
+	a := c.Analyzer
+	if a == nil {
+		a = zerologctx.Analyzer
+	}
+	saved := make(map[string]string)
+	a.Flags.VisitAll(func(f *flag.Flag) { saved[f.Name] = f.Value.String() })
+	t.Cleanup(func() {
+		for name, value := range saved {
+			if err := a.Flags.Set(name, value); err != nil {
+				t.Errorf("restoring -%s=%q: %v", name, value, err)
+			}
+		}
+	})
+	if c.ConfigFile != "" {
+		if err := zerologctx.LoadConfig(c.ConfigFile, a); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+	names := make([]string, 0, len(c.Flags))
+	for name := range c.Flags {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if a.Flags.Lookup(name) == nil {
+			t.Fatalf("unknown %s flag %q", a.Name, name)
+		}
+		if err := a.Flags.Set(name, c.Flags[name]); err != nil {
+			t.Fatalf("setting -%s=%q: %v", name, c.Flags[name], err)
+		}
+	}
+	return a
+}
+
+// gopath builds a temporary GOPATH holding the embedded stubs overlaid with
+// dir/src, and returns its root.
+func gopath(t Testing, dir string) string {
+	t.Helper()
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	err := fs.WalkDir(stubs, stubRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := stubs.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(stubRoot, filepath.FromSlash(path))
+		if err != nil {
+			return err
+		}
+		return writeFile(filepath.Join(src, rel), data)
+	})
+	if err != nil {
+		t.Fatalf("installing stubs: %v", err)
+	}
+	fixtures := filepath.Join(dir, "src")
+	err = filepath.WalkDir(fixtures, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(fixtures, path)
+		if err != nil {
+			return err
+		}
+		return writeFile(filepath.Join(src, rel), data)
+	})
+	if err != nil {
+		t.Fatalf("copying fixtures: %v", err)
+	}
+	return root
+}
+
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,120 @@
+package zerologctxtest_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tolmachov/zerologctx"
+	"github.com/tolmachov/zerologctx/zerologctxtest"
+)
+
+func TestRunWithSuggestedFixes(t *testing.T) {
+	zerologctxtest.RunWithSuggestedFixes(t, "testdata", "wrappedpkg")
+}
+
+// TestConfig verifies that flags and a policy file apply to the run and are
+// restored afterwards.
+func TestConfig(t *testing.T) {
+	allowStartup := zerologctxtest.Config{
+		Flags: map[string]string{"allow-funcs": "wrappedpkg.startup"},
+	}
+	t.Run("flags", func(t *testing.T) {
+		rt := &recordingT{T: t}
+		results := allowStartup.Run(rt, "testdata", "wrappedpkg")
+		if n := len(results[0].Diagnostics); n != 1 {
+			t.Errorf("got %d diagnostics with -allow-funcs, want 1", n)
+		}
+		rt.wantOnlyStartupUnmatched()
+	})
+	if v := zerologctx.Analyzer.Flags.Lookup("allow-funcs").Value.String(); v != "" {
+		t.Errorf("-allow-funcs = %q after the run, want it restored to empty", v)
+	}
+
+	t.Run("config file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".zerologctx.yml")
+		if err := os.WriteFile(path, []byte("allow-funcs: [wrappedpkg.startup]\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cfg := zerologctxtest.Config{ConfigFile: path}
+		rt := &recordingT{T: t}
+		results := cfg.Run(rt, "testdata", "wrappedpkg")
+		if n := len(results[0].Diagnostics); n != 1 {
+			t.Errorf("got %d diagnostics with the policy file, want 1", n)
+		}
+		rt.wantOnlyStartupUnmatched()
+	})
+}
+
+// recordingT records the analysistest failures instead of failing the test,
+// so the one expected failure can be told apart from any other.
+type recordingT struct {
+	*testing.T
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// wantOnlyStartupUnmatched fails the test unless the only failure recorded
+// is the "// want" comment of the startup call, which allowing its function
+// leaves without a diagnostic. The call's line is looked up in the fixture.
+func (r *recordingT) wantOnlyStartupUnmatched() {
+	r.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "src", "wrappedpkg", "wrappedpkg.go"))
+	if err != nil {
+		r.T.Fatal(err)
+	}
+	line := slices.IndexFunc(strings.Split(string(data), "\n"), func(l string) bool {
+		return strings.Contains(l, `Msg("startup")`)
+	}) + 1
+	if line == 0 {
+		r.T.Fatal(`wrappedpkg.go has no Msg("startup") call`)
+	}
+	want := fmt.Sprintf("wrappedpkg.go:%d: no diagnostic was reported matching", line)
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], want) {
+		r.T.Errorf("analysistest failures = %q, want exactly one containing %q", r.errors, want)
+	}
+}
+
+// TestStubsInSync verifies that the embedded stubs match the ones the
+// analyzer's own tests use.
+func TestStubsInSync(t *testing.T) {
+	stubs := filepath.Join("testdata", "stubs")
+	root := filepath.Join("..", "testdata", "src")
+	n := 0
+	err := filepath.WalkDir(stubs, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(stubs, path)
+		if err != nil {
+			return err
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		want, err := os.ReadFile(filepath.Join(root, rel))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s differs from %s; copy it over", path, filepath.Join(root, rel))
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		t.Fatal("no stubs found")
+	}
+}